@@ -4,6 +4,12 @@ import (
 	"github.com/stancarney/govoipms/v1"
 )
 
+//Options are applied in order, i.e. v1.WithDebug(true) or v1.WithTimeout(10*time.Second).
+func NewClient(url, username, password string, options ...v1.Option) *v1.VOIPClient {
+	return v1.NewVOIPClient(url, username, password, options...)
+}
+
+//Deprecated: use NewClient with v1.WithDebug.
 func NewV1Client(url, username, password string, debug bool) *v1.VOIPClient {
-	return &v1.VOIPClient{url, username, password, debug}
-}
\ No newline at end of file
+	return NewClient(url, username, password, v1.WithDebug(debug))
+}
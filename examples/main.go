@@ -9,7 +9,7 @@ import (
 
 // Calling the various functions should be straight forward but I left this here to show how I tested the implemented functions.
 func main() {
-	v1c := govoipms.NewV1Client("https://voip.ms/api/v1/rest.php", "email", "password", true)
+	v1c := govoipms.NewClient("https://voip.ms/api/v1/rest.php", "email", "password", v1.WithDebug(true))
 	GeneralFunctions(v1c)
 	//AccountFunctions(v1c)
 	//CDRFunctions(v1c) 
@@ -1,9 +1,8 @@
 package v1
 
 import (
-	"errors"
+	"context"
 	"strconv"
-	"net/url"
 )
 
 type AccountsAPI struct {
@@ -25,30 +24,30 @@ type DelSubAccountReq struct {
 }
 
 type Account struct {
-	Id                  string `json:"id,omitempty" url:"id"`
-	Account             string `json:"account,omitempty" url:"account"`
+	Id                  string `json:"id,omitempty" url:"id,omitempty"`
+	Account             string `json:"account,omitempty" url:"account,omitempty"`
 	Username            string `json:"username" url:"username"`
 	Protocol            string `json:"protocol" url:"protocol"`
-	Description         string `json:"description,omitempty" url:"description"`
+	Description         string `json:"description,omitempty" url:"description,omitempty"`
 	AuthType            string `json:"auth_type" url:"auth_type"`
-	Password            string `json:"password,omitempty" url:"password"`
-	IP                  string `json:"ip,omitempty" url:"ip"`
+	Password            string `json:"password,omitempty" url:"password,omitempty"`
+	IP                  string `json:"ip,omitempty" url:"ip,omitempty"`
 	DeviceType          string `json:"device_type" url:"device_type"`
-	CalleridNumber      string `json:"callerid_number,omitempty" url:"callerid_number"`
-	CanadaRouting       string `json:"canada_routing,omitempty" url:"canada_routing"`
-	LockInternational   string `json:"lock_international" url:"lock_international"`
+	CalleridNumber      string `json:"callerid_number,omitempty" url:"callerid_number,omitempty"`
+	CanadaRouting       string `json:"canada_routing,omitempty" url:"canada_routing,omitempty"`
+	LockInternational   FlexInt `json:"lock_international" url:"lock_international"`
 	InternationalRoute  string `json:"international_route" url:"international_route"`
 	MusicOnHold         string `json:"music_on_hold" url:"music_on_hold"`
 	AllowedCodecs       string `json:"allowed_codecs" url:"allowed_codecs"`
 	DTMFMode            string `json:"dtmf_mode" url:"dtmf_mode"`
 	NAT                 string `json:"nat" url:"nat"`
-	InternalExtension   string `json:"internal_extension,omitempty" url:"internal_extension"`
-	InternalVoicemail   string `json:"internal_voicemail,omitempty" url:"internal_voicemail"`
-	InternalDialtime    string `json:"internal_dialtime,omitempty" url:"internal_dialtime"`
-	ResellerClient      string `json:"reseller_client,omitempty" url:"reseller_client"`
-	ResellerPackage     string `json:"reseller_package,omitempty" url:"reseller_package"`
-	ResellerNextbilling string `json:"reseller_nextbilling,omitempty" url:"reseller_nextbilling"`
-	ResellerChargesetup string `json:"reseller_chargesetup,omitempty" url:"reseller_chargesetup"`
+	InternalExtension   string `json:"internal_extension,omitempty" url:"internal_extension,omitempty"`
+	InternalVoicemail   string `json:"internal_voicemail,omitempty" url:"internal_voicemail,omitempty"`
+	InternalDialtime    string `json:"internal_dialtime,omitempty" url:"internal_dialtime,omitempty"`
+	ResellerClient      string `json:"reseller_client,omitempty" url:"reseller_client,omitempty"`
+	ResellerPackage     string `json:"reseller_package,omitempty" url:"reseller_package,omitempty"`
+	ResellerNextbilling string `json:"reseller_nextbilling,omitempty" url:"reseller_nextbilling,omitempty"`
+	ResellerChargesetup string `json:"reseller_chargesetup,omitempty" url:"reseller_chargesetup,omitempty"`
 }
 
 type GetAllowedCodecsResp struct {
@@ -84,7 +83,7 @@ type GetLockInternationalResp struct {
 	LockInternational []LockInternational `json:"lock_international"`
 }
 
-//The Value type seems to switch between int and string for some reason, so it needs a custom struct for now. TODO:Re-evaluate
+//The Value switches between int and string, which FlexInt handles.
 type LockInternational NumberValueDescription
 
 type GetMusicOnHoldResp struct {
@@ -145,9 +144,13 @@ type SetSubAccountResp struct {
 }
 
 func (a *AccountsAPI) CreateSubAccount(subAccount *Account) error {
+	return a.CreateSubAccountContext(context.Background(), subAccount)
+}
+
+func (a *AccountsAPI) CreateSubAccountContext(ctx context.Context, subAccount *Account) error {
 
 	rs := &CreateSubAccountResp{}
-	if err := a.client.Post("createSubAccount", subAccount, rs); err != nil {
+	if err := a.client.PostContext(ctx, "createSubAccount", subAccount, rs); err != nil {
 		return err
 	}
 
@@ -158,9 +161,13 @@ func (a *AccountsAPI) CreateSubAccount(subAccount *Account) error {
 }
 
 func (a *AccountsAPI) DelSubAccount(id string) error {
+	return a.DelSubAccountContext(context.Background(), id)
+}
+
+func (a *AccountsAPI) DelSubAccountContext(ctx context.Context, id string) error {
 	rq := &DelSubAccountReq{id}
 	rs := &DelSubAccountResp{}
-	if err := a.client.Post("delSubAccount", rq, rs); err != nil {
+	if err := a.client.PostContext(ctx, "delSubAccount", rq, rs); err != nil {
 		return err
 	}
 
@@ -168,13 +175,16 @@ func (a *AccountsAPI) DelSubAccount(id string) error {
 }
 
 func (a *AccountsAPI) GetAllowedCodecs(codec string) ([]Codec, error) {
-	values := url.Values{}
-	if codec != "" {
-		values.Add("codec", codec)
-	}
+	return a.GetAllowedCodecsContext(context.Background(), codec)
+}
+
+func (a *AccountsAPI) GetAllowedCodecsContext(ctx context.Context, codec string) ([]Codec, error) {
+	rq := struct {
+		Codec string `url:"codec,omitempty"`
+	}{codec}
 
 	rs := &GetAllowedCodecsResp{}
-	if err := a.client.Get("getAllowedCodecs", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getAllowedCodecs", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -182,13 +192,16 @@ func (a *AccountsAPI) GetAllowedCodecs(codec string) ([]Codec, error) {
 }
 
 func (a *AccountsAPI) GetAuthTypes(authType int) ([]AuthType, error) {
-	values := url.Values{}
-	if authType > 0 {
-		values.Add("type", strconv.Itoa(authType))
-	}
+	return a.GetAuthTypesContext(context.Background(), authType)
+}
+
+func (a *AccountsAPI) GetAuthTypesContext(ctx context.Context, authType int) ([]AuthType, error) {
+	rq := struct {
+		Type int `url:"type,omitempty"`
+	}{authType}
 
 	rs := &GetAuthTypesResp{}
-	if err := a.client.Get("getAuthTypes", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getAuthTypes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -196,13 +209,16 @@ func (a *AccountsAPI) GetAuthTypes(authType int) ([]AuthType, error) {
 }
 
 func (a *AccountsAPI) GetDeviceTypes(deviceType int) ([]DeviceType, error) {
-	values := url.Values{}
-	if deviceType > 0 {
-		values.Add("device_type", strconv.Itoa(deviceType))
-	}
+	return a.GetDeviceTypesContext(context.Background(), deviceType)
+}
+
+func (a *AccountsAPI) GetDeviceTypesContext(ctx context.Context, deviceType int) ([]DeviceType, error) {
+	rq := struct {
+		DeviceType int `url:"device_type,omitempty"`
+	}{deviceType}
 
 	rs := &GetDeviceTypesResp{}
-	if err := a.client.Get("getDeviceTypes", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getDeviceTypes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -210,13 +226,16 @@ func (a *AccountsAPI) GetDeviceTypes(deviceType int) ([]DeviceType, error) {
 }
 
 func (a *AccountsAPI) GetDTMFModes(DTMFMode string) ([]DTMFMode, error) {
-	values := url.Values{}
-	if DTMFMode != "" {
-		values.Add("dtmf_mode", DTMFMode)
-	}
+	return a.GetDTMFModesContext(context.Background(), DTMFMode)
+}
+
+func (a *AccountsAPI) GetDTMFModesContext(ctx context.Context, DTMFMode string) ([]DTMFMode, error) {
+	rq := struct {
+		DTMFMode string `url:"dtmf_mode,omitempty"`
+	}{DTMFMode}
 
 	rs := &GetDTMFModesResp{}
-	if err := a.client.Get("getDTMFModes", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getDTMFModes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -226,13 +245,16 @@ func (a *AccountsAPI) GetDTMFModes(DTMFMode string) ([]DTMFMode, error) {
 //0 is an actual value for a Lock International entity so the signature of this message is a string opposed to an int.
 //This was done to avoid confusion with other functions that take 0 in order to return all values.
 func (a *AccountsAPI) GetLockInternational(lockInternational string) ([]LockInternational, error) {
-	values := url.Values{}
-	if lockInternational != "" {
-		values.Add("lock_international", lockInternational)
-	}
+	return a.GetLockInternationalContext(context.Background(), lockInternational)
+}
+
+func (a *AccountsAPI) GetLockInternationalContext(ctx context.Context, lockInternational string) ([]LockInternational, error) {
+	rq := struct {
+		LockInternational string `url:"lock_international,omitempty"`
+	}{lockInternational}
 
 	rs := &GetLockInternationalResp{}
-	if err := a.client.Get("getLockInternational", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getLockInternational", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -240,13 +262,16 @@ func (a *AccountsAPI) GetLockInternational(lockInternational string) ([]LockInte
 }
 
 func (a *AccountsAPI) GetMusicOnHold(musicOnHold string) ([]MusicOnHold, error) {
-	values := url.Values{}
-	if musicOnHold != "" {
-		values.Add("music_on_hold", musicOnHold)
-	}
+	return a.GetMusicOnHoldContext(context.Background(), musicOnHold)
+}
+
+func (a *AccountsAPI) GetMusicOnHoldContext(ctx context.Context, musicOnHold string) ([]MusicOnHold, error) {
+	rq := struct {
+		MusicOnHold string `url:"music_on_hold,omitempty"`
+	}{musicOnHold}
 
 	rs := &GetMusicOnHoldResp{}
-	if err := a.client.Get("getMusicOnHold", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getMusicOnHold", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -254,13 +279,16 @@ func (a *AccountsAPI) GetMusicOnHold(musicOnHold string) ([]MusicOnHold, error)
 }
 
 func (a *AccountsAPI) GetNAT(NAT string) ([]NAT, error) {
-	values := url.Values{}
-	if NAT != "" {
-		values.Add("nat", NAT)
-	}
+	return a.GetNATContext(context.Background(), NAT)
+}
+
+func (a *AccountsAPI) GetNATContext(ctx context.Context, NAT string) ([]NAT, error) {
+	rq := struct {
+		NAT string `url:"nat,omitempty"`
+	}{NAT}
 
 	rs := &GetNATResp{}
-	if err := a.client.Get("getNAT", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getNAT", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -268,13 +296,16 @@ func (a *AccountsAPI) GetNAT(NAT string) ([]NAT, error) {
 }
 
 func (a *AccountsAPI) GetProtocols(protocol int) ([]Protocol, error) {
-	values := url.Values{}
-	if protocol > 0 {
-		values.Add("protocol", strconv.Itoa(protocol))
-	}
+	return a.GetProtocolsContext(context.Background(), protocol)
+}
+
+func (a *AccountsAPI) GetProtocolsContext(ctx context.Context, protocol int) ([]Protocol, error) {
+	rq := struct {
+		Protocol int `url:"protocol,omitempty"`
+	}{protocol}
 
 	rs := &GetProtocolResp{}
-	if err := a.client.Get("getProtocols", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getProtocols", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -282,15 +313,20 @@ func (a *AccountsAPI) GetProtocols(protocol int) ([]Protocol, error) {
 }
 
 func (a *AccountsAPI) GetRegistrationStatus(account string) (bool, []RegistrationStatus, error) {
+	return a.GetRegistrationStatusContext(context.Background(), account)
+}
+
+func (a *AccountsAPI) GetRegistrationStatusContext(ctx context.Context, account string) (bool, []RegistrationStatus, error) {
 	if account == "" {
-		return false, nil, errors.New("missing_account")
+		return false, nil, newAPIError("getRegistrationStatus", "missing_account", 0)
 	}
 
-	values := url.Values{}
-	values.Add("account", account)
+	rq := struct {
+		Account string `url:"account"`
+	}{account}
 
 	rs := &GetRegistrationStatusResp{}
-	if err := a.client.Get("getRegistrationStatus", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getRegistrationStatus", rq, rs); err != nil {
 		return false, nil, err
 	}
 
@@ -298,13 +334,16 @@ func (a *AccountsAPI) GetRegistrationStatus(account string) (bool, []Registratio
 }
 
 func (a *AccountsAPI) GetReportEstimatedHoldTime(typ3 string) ([]EstimatedHoldTime, error) {
-	values := url.Values{}
-	if typ3 != "" {
-		values.Add("type", typ3)
-	}
+	return a.GetReportEstimatedHoldTimeContext(context.Background(), typ3)
+}
+
+func (a *AccountsAPI) GetReportEstimatedHoldTimeContext(ctx context.Context, typ3 string) ([]EstimatedHoldTime, error) {
+	rq := struct {
+		Type string `url:"type,omitempty"`
+	}{typ3}
 
 	rs := &GetReportEstimatedHoldTimeResp{}
-	if err := a.client.Get("getReportEstimatedHoldTime", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getReportEstimatedHoldTime", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -312,13 +351,16 @@ func (a *AccountsAPI) GetReportEstimatedHoldTime(typ3 string) ([]EstimatedHoldTi
 }
 
 func (a *AccountsAPI) GetRoutes(route int) ([]Route, error) {
-	values := url.Values{}
-	if route > 0 {
-		values.Add("route", strconv.Itoa(route))
-	}
+	return a.GetRoutesContext(context.Background(), route)
+}
+
+func (a *AccountsAPI) GetRoutesContext(ctx context.Context, route int) ([]Route, error) {
+	rq := struct {
+		Route int `url:"route,omitempty"`
+	}{route}
 
 	rs := &GetRoutesResp{}
-	if err := a.client.Get("getRoutes", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getRoutes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -326,13 +368,16 @@ func (a *AccountsAPI) GetRoutes(route int) ([]Route, error) {
 }
 
 func (a *AccountsAPI) GetSubAccounts(account string) ([]Account, error) {
-	values := url.Values{}
-	if account != "" {
-		values.Add("account", account)
-	}
+	return a.GetSubAccountsContext(context.Background(), account)
+}
+
+func (a *AccountsAPI) GetSubAccountsContext(ctx context.Context, account string) ([]Account, error) {
+	rq := struct {
+		Account string `url:"account,omitempty"`
+	}{account}
 
 	rs := &GetSubAccountsResp{}
-	if err := a.client.Get("getSubAccounts", values, rs); err != nil {
+	if err := a.client.GetContext(ctx, "getSubAccounts", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -340,8 +385,12 @@ func (a *AccountsAPI) GetSubAccounts(account string) ([]Account, error) {
 }
 
 func (a *AccountsAPI) SetSubAccount(account *Account) error {
+	return a.SetSubAccountContext(context.Background(), account)
+}
+
+func (a *AccountsAPI) SetSubAccountContext(ctx context.Context, account *Account) error {
 	rs := &SetSubAccountResp{}
-	if err := a.client.Post("setSubAccount", account, rs); err != nil {
+	if err := a.client.PostContext(ctx, "setSubAccount", account, rs); err != nil {
 		return err
 	}
 
@@ -25,7 +25,7 @@ func TestAccountsAPI_CreateSubAccount(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	a := &Account{
 		Username: "Test1",
@@ -37,7 +37,7 @@ func TestAccountsAPI_CreateSubAccount(t *testing.T) {
 		DeviceType: "2",
 		CalleridNumber: "5555551234",
 		CanadaRouting: "1",
-		LockInternational: "1",
+		LockInternational: 1,
 		InternationalRoute: "1",
 		MusicOnHold: "default",
 		AllowedCodecs: "ulaw;g729",
@@ -75,7 +75,7 @@ func TestAccountsAPI_CreateSubAccount_Error(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	a := &Account{
 		Username: "Test1",
@@ -87,7 +87,7 @@ func TestAccountsAPI_CreateSubAccount_Error(t *testing.T) {
 		DeviceType: "2",
 		CalleridNumber: "5555551234",
 		CanadaRouting: "1",
-		LockInternational: "1",
+		LockInternational: 1,
 		InternationalRoute: "1",
 		MusicOnHold: "default",
 		AllowedCodecs: "ulaw;g729",
@@ -123,7 +123,7 @@ func TestAccountsAPI_DelSubAccount(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	err := api.DelSubAccount("12345")
@@ -145,7 +145,7 @@ func TestAccountsAPI_DelSubAccount_Error(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	err := api.DelSubAccount("12345")
@@ -164,12 +164,12 @@ func TestAccountsAPI_GetAllowedCodecs(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAllowedCodecs"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getAllowedCodecs"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	codecs, err := api.GetAllowedCodecs("")
@@ -190,12 +190,12 @@ func TestAccountsAPI_GetAllowedCodecs_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAllowedCodecs"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getAllowedCodecs"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	codecs, err := api.GetAllowedCodecs("")
@@ -215,13 +215,13 @@ func TestAccountsAPI_GetAllowedCodecs_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAllowedCodecs"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"911"}, r.URL.Query()["codec"])
+		require.Equal(t, []string{"getAllowedCodecs"}, formValues(r)["method"])
+		require.Equal(t, []string{"911"}, formValues(r)["codec"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	codecs, err := api.GetAllowedCodecs("911")
@@ -237,17 +237,17 @@ func TestAccountsAPI_GetAuthTypes(t *testing.T) {
 	//setup
 	rq := GetAuthTypesResp{
 		BaseResp{"success"},
-		[]AuthType{{"1", "one"}, {"2", "two"}},
+		[]AuthType{{1, "one"}, {2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAuthTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getAuthTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	authTypes, err := api.GetAuthTypes(0)
@@ -268,12 +268,12 @@ func TestAccountsAPI_GetAuthTypes_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAuthTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getAuthTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	authTypes, err := api.GetAuthTypes(0)
@@ -288,18 +288,18 @@ func TestAccountsAPI_GetAuthTypes_Specified(t *testing.T) {
 	//setup
 	rq := GetAuthTypesResp{
 		BaseResp{"success"},
-		[]AuthType{{"2", "two"}},
+		[]AuthType{{2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getAuthTypes"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["type"])
+		require.Equal(t, []string{"getAuthTypes"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["type"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	authTypes, err := api.GetAuthTypes(2)
@@ -315,17 +315,17 @@ func TestAccountsAPI_GetDeviceTypes(t *testing.T) {
 	//setup
 	rq := GetDeviceTypesResp{
 		BaseResp{"success"},
-		[]DeviceType{{"1", "one"}, {"2", "two"}},
+		[]DeviceType{{1, "one"}, {2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDeviceTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getDeviceTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	deviceTypes, err := api.GetDeviceTypes(0)
@@ -346,12 +346,12 @@ func TestAccountsAPI_GetDeviceTypes_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDeviceTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getDeviceTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	authTypes, err := api.GetDeviceTypes(0)
@@ -366,18 +366,18 @@ func TestAccountsAPI_GetDeviceTypes_Specified(t *testing.T) {
 	//setup
 	rq := GetDeviceTypesResp{
 		BaseResp{"success"},
-		[]DeviceType{{"2", "two"}},
+		[]DeviceType{{2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDeviceTypes"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["device_type"])
+		require.Equal(t, []string{"getDeviceTypes"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["device_type"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	deviceTypes, err := api.GetDeviceTypes(2)
@@ -398,12 +398,12 @@ func TestAccountsAPI_GetDTMFModes(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDTMFModes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getDTMFModes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	dtmfModes, err := api.GetDTMFModes("")
@@ -424,12 +424,12 @@ func TestAccountsAPI_GetDTMFModes_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDTMFModes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getDTMFModes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	dtmfModes, err := api.GetDTMFModes("")
@@ -449,13 +449,13 @@ func TestAccountsAPI_GetDTMFModes_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getDTMFModes"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["dtmf_mode"])
+		require.Equal(t, []string{"getDTMFModes"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["dtmf_mode"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	dtmfModes, err := api.GetDTMFModes("2")
@@ -471,17 +471,17 @@ func TestAccountsAPI_GetLockInternational(t *testing.T) {
 	//setup
 	rq := GetLockInternationalResp{
 		BaseResp{"success"},
-		[]LockInternational{{"1", "one"}, {"2", "two"}},
+		[]LockInternational{{1, "one"}, {2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLockInternational"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getLockInternational"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	lockInternational, err := api.GetLockInternational("")
@@ -502,12 +502,12 @@ func TestAccountsAPI_GetLockInternational_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLockInternational"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getLockInternational"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	lockInternational, err := api.GetLockInternational("")
@@ -522,18 +522,18 @@ func TestAccountsAPI_GetLockInternational_Specified(t *testing.T) {
 	//setup
 	rq := GetLockInternationalResp{
 		BaseResp{"success"},
-		[]LockInternational{{"2", "two"}},
+		[]LockInternational{{2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLockInternational"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["lock_international"])
+		require.Equal(t, []string{"getLockInternational"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["lock_international"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	lockInternational, err := api.GetLockInternational("2")
@@ -554,12 +554,12 @@ func TestAccountsAPI_GetMusicOnHold(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getMusicOnHold"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getMusicOnHold"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	musicOnHold, err := api.GetMusicOnHold("")
@@ -580,12 +580,12 @@ func TestAccountsAPI_GetMusicOnHold_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getMusicOnHold"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getMusicOnHold"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	musicOnHold, err := api.GetMusicOnHold("")
@@ -605,13 +605,13 @@ func TestAccountsAPI_GetMusicOnHold_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getMusicOnHold"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["music_on_hold"])
+		require.Equal(t, []string{"getMusicOnHold"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["music_on_hold"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	musicOnHold, err := api.GetMusicOnHold("2")
@@ -632,12 +632,12 @@ func TestAccountsAPI_GetNAT(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getNAT"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getNAT"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	NAT, err := api.GetNAT("")
@@ -658,12 +658,12 @@ func TestAccountsAPI_GetNAT_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getNAT"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getNAT"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	NAT, err := api.GetNAT("")
@@ -683,13 +683,13 @@ func TestAccountsAPI_GetNAT_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getNAT"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["nat"])
+		require.Equal(t, []string{"getNAT"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["nat"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	NAT, err := api.GetNAT("2")
@@ -705,17 +705,17 @@ func TestAccountsAPI_GetProtocols(t *testing.T) {
 	//setup
 	rq := GetProtocolResp{
 		BaseResp{"success"},
-		[]Protocol{{"1", "one"}, {"2", "two"}},
+		[]Protocol{{1, "one"}, {2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getProtocols"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getProtocols"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	protocols, err := api.GetProtocols(0)
@@ -736,12 +736,12 @@ func TestAccountsAPI_GetProtocols_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getProtocols"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getProtocols"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	protocols, err := api.GetProtocols(0)
@@ -756,18 +756,18 @@ func TestAccountsAPI_GetProtocols_Specified(t *testing.T) {
 	//setup
 	rq := GetProtocolResp{
 		BaseResp{"success"},
-		[]Protocol{{"2", "two"}},
+		[]Protocol{{2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getProtocols"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["protocol"])
+		require.Equal(t, []string{"getProtocols"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["protocol"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	protocols, err := api.GetProtocols(2)
@@ -789,12 +789,12 @@ func TestAccountsAPI_GetRegistrationStatus(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRegistrationStatus"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRegistrationStatus"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	registered, registrations, err := api.GetRegistrationStatus("")
@@ -816,12 +816,12 @@ func TestAccountsAPI_GetRegistrationStatus_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRegistrationStatus"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRegistrationStatus"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	registered, registrations, err := api.GetRegistrationStatus("2")
@@ -855,13 +855,13 @@ func TestAccountsAPI_GetRegistrationStatus_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRegistrationStatus"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["account"])
+		require.Equal(t, []string{"getRegistrationStatus"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["account"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	registered, registrations, err := api.GetRegistrationStatus("2")
@@ -883,12 +883,12 @@ func TestAccountsAPI_GetReportEstimatedHoldTime(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getReportEstimatedHoldTime"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getReportEstimatedHoldTime"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	types, err := api.GetReportEstimatedHoldTime("")
@@ -909,12 +909,12 @@ func TestAccountsAPI_GetReportEstimatedHoldTime_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getReportEstimatedHoldTime"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getReportEstimatedHoldTime"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	types, err := api.GetReportEstimatedHoldTime("")
@@ -934,13 +934,13 @@ func TestAccountsAPI_GetReportEstimatedHoldTime_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getReportEstimatedHoldTime"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2"}, r.URL.Query()["type"])
+		require.Equal(t, []string{"getReportEstimatedHoldTime"}, formValues(r)["method"])
+		require.Equal(t, []string{"2"}, formValues(r)["type"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	types, err := api.GetReportEstimatedHoldTime("2")
@@ -956,17 +956,17 @@ func TestAccountsAPI_GetRoutes(t *testing.T) {
 	//setup
 	rq := GetRoutesResp{
 		BaseResp{"success"},
-		[]Route{{"1", "one"}, {"2", "two"}},
+		[]Route{{1, "one"}, {2, "two"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRoutes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRoutes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	routes, err := api.GetRoutes(0)
@@ -987,12 +987,12 @@ func TestAccountsAPI_GetRoutes_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRoutes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRoutes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	routes, err := api.GetRoutes(0)
@@ -1007,18 +1007,18 @@ func TestAccountsAPI_GetRoutes_Specified(t *testing.T) {
 	//setup
 	rq := GetRoutesResp{
 		BaseResp{"success"},
-		[]Route{{"1", "one"}},
+		[]Route{{1, "one"}},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRoutes"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["route"])
+		require.Equal(t, []string{"getRoutes"}, formValues(r)["method"])
+		require.Equal(t, []string{"1"}, formValues(r)["route"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	routes, err := api.GetRoutes(1)
@@ -1039,12 +1039,12 @@ func TestAccountsAPI_GetSubAccounts(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getSubAccounts"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getSubAccounts"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	accounts, err := api.GetSubAccounts("")
@@ -1065,12 +1065,12 @@ func TestAccountsAPI_GetSubAccounts_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getSubAccounts"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getSubAccounts"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	accounts, err := api.GetSubAccounts("")
@@ -1090,13 +1090,13 @@ func TestAccountsAPI_GetSubAccounts_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getSubAccounts"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["account"])
+		require.Equal(t, []string{"getSubAccounts"}, formValues(r)["method"])
+		require.Equal(t, []string{"1"}, formValues(r)["account"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	//execute
 	accounts, err := api.GetSubAccounts("1")
@@ -1120,7 +1120,7 @@ func TestAccountsAPI_SetSubAccount(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	a := &Account{
 		Username: "Test1",
@@ -1132,7 +1132,7 @@ func TestAccountsAPI_SetSubAccount(t *testing.T) {
 		DeviceType: "2",
 		CalleridNumber: "5555551234",
 		CanadaRouting: "1",
-		LockInternational: "1",
+		LockInternational: 1,
 		InternationalRoute: "1",
 		MusicOnHold: "default",
 		AllowedCodecs: "ulaw;g729",
@@ -1166,7 +1166,7 @@ func TestAccountsAPI_SetSubAccount_Error(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewAccountsAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewAccountsAPI()
 
 	a := &Account{
 		Username: "Test1",
@@ -1178,7 +1178,7 @@ func TestAccountsAPI_SetSubAccount_Error(t *testing.T) {
 		DeviceType: "2",
 		CalleridNumber: "5555551234",
 		CanadaRouting: "1",
-		LockInternational: "1",
+		LockInternational: 1,
 		InternationalRoute: "1",
 		MusicOnHold: "default",
 		AllowedCodecs: "ulaw;g729",
@@ -0,0 +1,302 @@
+//Package analytics aggregates CDRs into reports: calls, minutes and cost per sub account, destination, disposition or
+//period, with answer seizure ratio, average call duration and duration percentiles.
+//
+//	report, err := analytics.AggregateIterator(cdrAPI.Query(ctx, q), analytics.ByAccount)
+//	for _, g := range report.Groups {
+//		fmt.Println(g.Key, g.Calls, g.Minutes(), g.Cost, g.ASR)
+//	}
+//	top := report.Top(10, analytics.MetricCost)
+//
+//Memory grows with the number of groups and distinct call lengths, not the number of CDRs.
+package analytics
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//voip.ms's disposition for a call that connected.
+const DispositionAnswered = "ANSWERED"
+
+//Returns the group a CDR belongs to.
+type KeyFunc func(cdr v1.CDR) string
+
+//Groups by sub account.
+func ByAccount(cdr v1.CDR) string {
+	return cdr.Account
+}
+
+//Groups by voip.ms's destination description, i.e. "Canada - Alberta" or "Inbound DID".
+func ByDescription(cdr v1.CDR) string {
+	return cdr.Description
+}
+
+//Groups by the number dialled.
+func ByDestination(cdr v1.CDR) string {
+	return cdr.Destination
+}
+
+//Groups by disposition, i.e. ANSWERED, NO ANSWER, BUSY or FAILED.
+func ByDisposition(cdr v1.CDR) string {
+	return cdr.Disposition
+}
+
+//Groups by caller id, for top callers.
+func ByCallerID(cdr v1.CDR) string {
+	return cdr.CallerId
+}
+
+//Groups by the call's date in loc as 2006-01-02. nil keeps each CDR's own timezone.
+func ByDay(loc *time.Location) KeyFunc {
+	return byTime(loc, "2006-01-02")
+}
+
+//Groups by the call's hour in loc as 2006-01-02 15:00.
+func ByHour(loc *time.Location) KeyFunc {
+	return byTime(loc, "2006-01-02 15:00")
+}
+
+//Groups by the call's month in loc as 2006-01.
+func ByMonth(loc *time.Location) KeyFunc {
+	return byTime(loc, "2006-01")
+}
+
+//Groups by the hour of the day in loc, 00 to 23, for traffic profiles.
+func ByHourOfDay(loc *time.Location) KeyFunc {
+	return byTime(loc, "15")
+}
+
+func byTime(loc *time.Location, layout string) KeyFunc {
+	return func(cdr v1.CDR) string {
+		date := cdr.Date
+		if loc != nil {
+			date = date.In(loc)
+		}
+		return date.Format(layout)
+	}
+}
+
+//Groups by several keys at once. The group key is each key joined with " / ", i.e. "100000_office / 2020-01-02".
+func Combine(keys ...KeyFunc) KeyFunc {
+	return func(cdr v1.CDR) string {
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key(cdr)
+		}
+		return strings.Join(parts, " / ")
+	}
+}
+
+//Totals for a set of CDRs. Durations and percentiles are of answered calls only.
+type Stats struct {
+	Calls    int           `json:"calls"`
+	Answered int           `json:"answered"`
+	Seconds  int64         `json:"seconds"` //Billed seconds, CDR.Seconds summed.
+	Duration time.Duration `json:"duration"`
+	Cost     v1.Money      `json:"cost"`
+
+	ASR float64       `json:"asr"` //Answer seizure ratio, Answered / Calls.
+	ACD time.Duration `json:"acd"` //Average duration of an answered call.
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+}
+
+//Billed minutes.
+func (s Stats) Minutes() float64 {
+	return float64(s.Seconds) / 60
+}
+
+//The Stats of one group.
+type Group struct {
+	Key string `json:"key"`
+	Stats
+}
+
+type Report struct {
+	Groups []Group `json:"groups"` //Sorted by Key, so dates come out in order.
+	Total  Stats   `json:"total"`
+}
+
+//Writes one row per group followed by a "total" row. Durations are in seconds, ASR is a fraction.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"key", "calls", "answered", "asr", "minutes", "cost", "acd", "p50", "p90", "p95", "p99"})
+
+	row := func(key string, s Stats) {
+		secs := func(d time.Duration) string {
+			return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		}
+		cw.Write([]string{
+			key, strconv.Itoa(s.Calls), strconv.Itoa(s.Answered), strconv.FormatFloat(s.ASR, 'f', 4, 64),
+			strconv.FormatFloat(s.Minutes(), 'f', 2, 64), s.Cost.String(), secs(s.ACD), secs(s.P50), secs(s.P90),
+			secs(s.P95), secs(s.P99),
+		})
+	}
+	for _, g := range r.Groups {
+		row(g.Key, g.Stats)
+	}
+	row("total", r.Total)
+
+	cw.Flush()
+	return cw.Error()
+}
+
+//Orders groups for Report.Top.
+type Metric int
+
+const (
+	MetricCalls Metric = iota
+	MetricMinutes
+	MetricCost
+	MetricASR
+)
+
+func (m Metric) value(s Stats) float64 {
+	switch m {
+	case MetricMinutes:
+		return float64(s.Seconds)
+	case MetricCost:
+		return float64(s.Cost.Units())
+	case MetricASR:
+		return s.ASR
+	}
+	return float64(s.Calls)
+}
+
+//Returns up to n groups with the highest metric, highest first. Ties are broken by Key.
+func (r Report) Top(n int, metric Metric) []Group {
+	groups := append([]Group(nil), r.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return metric.value(groups[i].Stats) > metric.value(groups[j].Stats)
+	})
+
+	if n < len(groups) {
+		groups = groups[:n]
+	}
+	return groups
+}
+
+//Running totals for one group.
+type accumulator struct {
+	stats     Stats
+	durations map[int64]int //Answered calls by length in whole seconds.
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{durations: map[int64]int{}}
+}
+
+func (a *accumulator) add(cdr v1.CDR) {
+	a.stats.Calls++
+	a.stats.Seconds += int64(cdr.Seconds.Int())
+	a.stats.Cost = a.stats.Cost.Add(cdr.Total)
+
+	if strings.EqualFold(cdr.Disposition, DispositionAnswered) {
+		a.stats.Answered++
+		a.stats.Duration += cdr.Duration
+		a.durations[int64(cdr.Duration/time.Second)]++
+	}
+}
+
+func (a *accumulator) result() Stats {
+	s := a.stats
+	if s.Calls > 0 {
+		s.ASR = float64(s.Answered) / float64(s.Calls)
+	}
+	if s.Answered > 0 {
+		s.ACD = s.Duration / time.Duration(s.Answered)
+	}
+
+	lengths := make([]int64, 0, len(a.durations))
+	for length := range a.durations {
+		lengths = append(lengths, length)
+	}
+	sort.Slice(lengths, func(i, j int) bool { return lengths[i] < lengths[j] })
+
+	s.P50 = a.percentile(lengths, 50)
+	s.P90 = a.percentile(lengths, 90)
+	s.P95 = a.percentile(lengths, 95)
+	s.P99 = a.percentile(lengths, 99)
+
+	return s
+}
+
+//Nearest rank percentile of answered call lengths, to the second.
+func (a *accumulator) percentile(lengths []int64, p int) time.Duration {
+	if a.stats.Answered == 0 {
+		return 0
+	}
+
+	rank := (p*a.stats.Answered + 99) / 100
+	seen := 0
+	for _, length := range lengths {
+		seen += a.durations[length]
+		if seen >= rank {
+			return time.Duration(length) * time.Second
+		}
+	}
+	return 0
+}
+
+//Builds a Report from CDRs added one at a time. It isn't safe for concurrent use.
+type Aggregator struct {
+	key    KeyFunc
+	groups map[string]*accumulator
+	total  *accumulator
+}
+
+//Groups CDRs by key. A nil key puts every CDR in a single group with an empty key.
+func NewAggregator(key KeyFunc) *Aggregator {
+	if key == nil {
+		key = func(v1.CDR) string { return "" }
+	}
+	return &Aggregator{key: key, groups: map[string]*accumulator{}, total: newAccumulator()}
+}
+
+func (a *Aggregator) Add(cdr v1.CDR) {
+	k := a.key(cdr)
+	group, ok := a.groups[k]
+	if !ok {
+		group = newAccumulator()
+		a.groups[k] = group
+	}
+
+	group.add(cdr)
+	a.total.add(cdr)
+}
+
+//Returns the report for everything added so far. More CDRs can still be added afterwards.
+func (a *Aggregator) Report() Report {
+	r := Report{Groups: make([]Group, 0, len(a.groups)), Total: a.total.result()}
+	for k, group := range a.groups {
+		r.Groups = append(r.Groups, Group{k, group.result()})
+	}
+
+	sort.Slice(r.Groups, func(i, j int) bool { return r.Groups[i].Key < r.Groups[j].Key })
+	return r
+}
+
+func Aggregate(cdrs []v1.CDR, key KeyFunc) Report {
+	a := NewAggregator(key)
+	for _, cdr := range cdrs {
+		a.Add(cdr)
+	}
+	return a.Report()
+}
+
+//Aggregates everything it returns. On error the report covers the CDRs read before it.
+func AggregateIterator(it *v1.CDRIterator, key KeyFunc) (Report, error) {
+	a := NewAggregator(key)
+	for it.Next() {
+		a.Add(it.CDR())
+	}
+	return a.Report(), it.Err()
+}
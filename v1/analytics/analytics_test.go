@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func call(account, disposition string, date time.Time, seconds int, total string) v1.CDR {
+	return v1.CDR{
+		Date:        date,
+		Account:     account,
+		Description: "Canada - Alberta",
+		Disposition: disposition,
+		Duration:    time.Duration(seconds) * time.Second,
+		Seconds:     v1.FlexInt(seconds),
+		Total:       v1.MustParseMoney(total),
+	}
+}
+
+func TestAggregate(t *testing.T) {
+
+	//setup
+	day := time.Date(2020, 1, 2, 23, 30, 0, 0, time.UTC)
+	cdrs := []v1.CDR{
+		call("100000_office", "ANSWERED", day, 60, "0.0100"),
+		call("100000_office", "ANSWERED", day, 120, "0.0200"),
+		call("100000_office", "NO ANSWER", day, 0, "0"),
+		call("100000_home", "ANSWERED", day.Add(time.Hour), 30, "0.0050"),
+		call("100000_home", "BUSY", day.Add(time.Hour), 0, "0"),
+	}
+
+	//execute
+	report := Aggregate(cdrs, ByAccount)
+
+	//verify
+	require.Len(t, report.Groups, 2)
+
+	home := report.Groups[0]
+	require.Equal(t, "100000_home", home.Key)
+	require.Equal(t, 2, home.Calls)
+	require.Equal(t, 1, home.Answered)
+	require.Equal(t, 0.5, home.ASR)
+
+	office := report.Groups[1]
+	require.Equal(t, "100000_office", office.Key)
+	require.Equal(t, 3, office.Calls)
+	require.Equal(t, int64(180), office.Seconds)
+	require.Equal(t, 3.0, office.Minutes())
+	require.Equal(t, "0.0300", office.Cost.String())
+	require.InDelta(t, 2.0/3, office.ASR, 0.0001)
+	require.Equal(t, 90*time.Second, office.ACD)
+	require.Equal(t, 60*time.Second, office.P50)
+	require.Equal(t, 120*time.Second, office.P90)
+
+	require.Equal(t, 5, report.Total.Calls)
+	require.Equal(t, 3, report.Total.Answered)
+	require.Equal(t, "0.0350", report.Total.Cost.String())
+	require.Equal(t, 70*time.Second, report.Total.ACD)
+	require.Equal(t, 0.6, report.Total.ASR)
+}
+
+func TestAggregate_Periods(t *testing.T) {
+
+	//setup
+	mst, _ := time.LoadLocation("America/Edmonton")
+	day := time.Date(2020, 1, 2, 23, 30, 0, 0, time.UTC)
+	cdrs := []v1.CDR{
+		call("a", "ANSWERED", day, 60, "0.01"),
+		call("a", "ANSWERED", day.Add(time.Hour), 60, "0.01"),
+		call("b", "ANSWERED", day.Add(8*time.Hour), 60, "0.01"),
+	}
+
+	//execute
+	utc := Aggregate(cdrs, ByDay(time.UTC))
+	local := Aggregate(cdrs, ByDay(mst))
+	hours := Aggregate(cdrs, ByHourOfDay(mst))
+	months := Aggregate(cdrs, ByMonth(nil))
+	combined := Aggregate(cdrs, Combine(ByAccount, ByHour(time.UTC)))
+
+	//verify
+	keys := func(r Report) []string {
+		var k []string
+		for _, g := range r.Groups {
+			k = append(k, fmt.Sprintf("%s=%d", g.Key, g.Calls))
+		}
+		return k
+	}
+	require.Equal(t, []string{"2020-01-02=1", "2020-01-03=2"}, keys(utc))
+	require.Equal(t, []string{"2020-01-02=2", "2020-01-03=1"}, keys(local))
+	require.Equal(t, []string{"00=1", "16=1", "17=1"}, keys(hours))
+	require.Equal(t, []string{"2020-01=3"}, keys(months))
+	require.Equal(t, []string{"a / 2020-01-02 23:00=1", "a / 2020-01-03 00:00=1", "b / 2020-01-03 07:00=1"}, keys(combined))
+}
+
+func TestStats_Percentiles(t *testing.T) {
+
+	//setup
+	a := NewAggregator(nil)
+	for i := 1; i <= 100; i++ {
+		a.Add(call("a", "ANSWERED", time.Time{}, i, "0"))
+	}
+	a.Add(call("a", "FAILED", time.Time{}, 0, "0"))
+
+	//execute
+	report := a.Report()
+
+	//verify
+	require.Len(t, report.Groups, 1)
+	s := report.Groups[0].Stats
+	require.Equal(t, 50*time.Second, s.P50)
+	require.Equal(t, 90*time.Second, s.P90)
+	require.Equal(t, 95*time.Second, s.P95)
+	require.Equal(t, 99*time.Second, s.P99)
+	require.Equal(t, 50500*time.Millisecond, s.ACD)
+	require.Equal(t, Stats{}, Aggregate(nil, nil).Total)
+}
+
+func TestReport_Top(t *testing.T) {
+
+	//setup
+	var cdrs []v1.CDR
+	for i, caller := range []string{"a", "b", "b", "c", "c", "c"} {
+		cdr := call("x", "ANSWERED", time.Time{}, 60, "0.01")
+		cdr.CallerId = caller
+		if i == 0 {
+			cdr.Total = v1.MustParseMoney("1")
+		}
+		cdrs = append(cdrs, cdr)
+	}
+	report := Aggregate(cdrs, ByCallerID)
+
+	//execute
+	byCalls := report.Top(2, MetricCalls)
+	byCost := report.Top(1, MetricCost)
+	all := report.Top(10, MetricMinutes)
+
+	//verify
+	require.Equal(t, "c", byCalls[0].Key)
+	require.Equal(t, "b", byCalls[1].Key)
+	require.Len(t, byCalls, 2)
+	require.Equal(t, "a", byCost[0].Key)
+	require.Len(t, all, 3)
+	require.Equal(t, "a", report.Groups[0].Key)
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+
+	//setup
+	report := Aggregate([]v1.CDR{
+		call("a", "ANSWERED", time.Time{}, 90, "0.0150"),
+		call("a", "BUSY", time.Time{}, 0, "0"),
+	}, ByAccount)
+	buf := &bytes.Buffer{}
+
+	//execute
+	err := report.WriteCSV(buf)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "key,calls,answered,asr,minutes,cost,acd,p50,p90,p95,p99\n"+
+		"a,2,1,0.5000,1.50,0.0150,90,90,90,90,90\n"+
+		"total,2,1,0.5000,1.50,0.0150,90,90,90,90,90\n", buf.String())
+}
+
+func TestAggregateIterator(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		fmt.Fprintf(w, `{"status":"success","cdr":[{"date":"%s 10:00:00","account":"100000_office","disposition":"ANSWERED","duration":"00:01:00","seconds":"60","total":"0.01000000"}]}`, r.Form.Get("date_from"))
+	}))
+	defer ts.Close()
+
+	it := v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI().Query(context.Background(), v1.CDRQuery{
+		From:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 1, 10, 0, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		WindowDays: 1,
+	})
+
+	//execute
+	report, err := AggregateIterator(it, ByDay(time.UTC))
+
+	//verify
+	require.NoError(t, err)
+	require.Len(t, report.Groups, 10)
+	require.Equal(t, "2020-01-10", report.Groups[9].Key)
+	require.Equal(t, 10.0, report.Total.Minutes())
+	require.Equal(t, "0.1000", report.Total.Cost.String())
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+type actorKey struct{}
+
+//One mutating call as recorded by an AuditSink.
+type AuditEntry struct {
+	Time       time.Time     `json:"time"` //When the call was made.
+	Actor      string        `json:"actor,omitempty"`
+	Method     string        `json:"method"`
+	Params     url.Values    `json:"params"` //Credentials and secrets are Redacted.
+	Status     string        `json:"status"` //voip.ms status, "refused" when the client's Mode stopped it or "error" when no status came back.
+	HTTPStatus int           `json:"http_status,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"` //Nanoseconds.
+}
+
+//Stores AuditEntries. Record is called after every mutating call, including failed and refused ones, and may be
+//called concurrently.
+type AuditSink interface {
+	Record(entry AuditEntry) error
+}
+
+//Adapts a function to AuditSink.
+type AuditSinkFunc func(entry AuditEntry) error
+
+func (f AuditSinkFunc) Record(entry AuditEntry) error {
+	return f(entry)
+}
+
+//Records every call that mutates something to sink. The actor is taken from the call's context, see WithActor.
+//The call has already been made when the sink is written to so a sink error doesn't fail it, it's logged instead.
+func WithAudit(sink AuditSink) Option {
+	return func(c *VOIPClient) {
+		c.audit = sink
+	}
+}
+
+//Returns a context that has calls made with it audited as actor, i.e. a user name or job id.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+//Returns the actor set by WithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+//Wraps next to record mutating calls to the client's AuditSink. It's the outermost Handler so entries reflect what
+//the caller got back.
+func (c *VOIPClient) auditor(next Handler) Handler {
+	return func(ctx context.Context, inv *Invocation) error {
+		if !mutates(inv.Method) {
+			return next(ctx, inv)
+		}
+
+		start := time.Now()
+		err := next(ctx, inv)
+
+		entry := AuditEntry{
+			Time:       start,
+			Actor:      ActorFromContext(ctx),
+			Method:     inv.Method,
+			Params:     inv.Params,
+			Status:     inv.Status,
+			HTTPStatus: inv.HTTPStatus,
+			Duration:   time.Since(start),
+		}
+
+		refused := &RefusedError{}
+		if errors.As(err, &refused) {
+			entry.Status = "refused"
+		} else if entry.Status == "" && err != nil {
+			entry.Status = "error"
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		if rerr := c.audit.Record(entry); rerr != nil {
+			logger := c.logger
+			if logger == nil {
+				logger = StdLogger
+			}
+			logger.Log("voipms audit failed", "method", inv.Method, "error", rerr)
+		}
+
+		return err
+	}
+}
+
+//Appends AuditEntries to a writer as JSON Lines, one entry per line.
+type JSONLAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJSONLAuditSink(w io.Writer) *JSONLAuditSink {
+	return &JSONLAuditSink{w: w}
+}
+
+//Opens, or creates, the JSON Lines file at path for appending. Existing entries are never rewritten.
+func OpenAuditLog(path string) (*JSONLAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLAuditSink(f), nil
+}
+
+//Each entry is written with a single Write so concurrent writers to the same file don't interleave lines.
+func (s *JSONLAuditSink) Record(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(line)
+	return err
+}
+
+//Closes the underlying writer if it's an io.Closer.
+func (s *JSONLAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if closer, ok := s.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
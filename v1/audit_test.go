@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAudit(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("method") {
+		case "delSubAccount":
+			fmt.Fprintln(w, `{"status":"invalid_id"}`)
+		default:
+			fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+		}
+	}))
+	defer ts.Close()
+
+	var entries []AuditEntry
+	sink := AuditSinkFunc(func(entry AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithAudit(sink))
+	ctx := WithActor(context.Background(), "jane")
+
+	//execute
+	errCharge := c.NewClientsAPI().AddChargeContext(ctx, "1", "Monthly", MustParseMoney("5"), false)
+	_, errIP := c.NewGeneralAPI().GetIPContext(ctx)
+	errDel := c.NewAccountsAPI().DelSubAccount("99")
+
+	//verify
+	require.NoError(t, errCharge)
+	require.NoError(t, errIP)
+	require.EqualError(t, errDel, "invalid_id")
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "jane", entries[0].Actor)
+	require.Equal(t, "addCharge", entries[0].Method)
+	require.Equal(t, "5.0000", entries[0].Params.Get("charge"))
+	require.Equal(t, "", entries[0].Params.Get("api_password"))
+	require.Equal(t, "success", entries[0].Status)
+	require.Equal(t, http.StatusOK, entries[0].HTTPStatus)
+	require.Equal(t, "", entries[0].Error)
+	require.WithinDuration(t, time.Now(), entries[0].Time, time.Minute)
+	require.True(t, entries[0].Duration > 0)
+
+	require.Equal(t, "", entries[1].Actor)
+	require.Equal(t, "delSubAccount", entries[1].Method)
+	require.Equal(t, "invalid_id", entries[1].Status)
+	require.Equal(t, "invalid_id", entries[1].Error)
+}
+
+func TestWithAudit_Refused(t *testing.T) {
+
+	//setup
+	var entries []AuditEntry
+	sink := AuditSinkFunc(func(entry AuditEntry) error {
+		entries = append(entries, entry)
+		return fmt.Errorf("disk full")
+	})
+
+	var logged []string
+	logger := LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, msg)
+	})
+
+	c := NewVOIPClient("http://localhost:1", "", "", WithReadOnly(), WithAudit(sink), WithLogger(logger))
+
+	//execute
+	err := c.NewAccountsAPI().DelSubAccount("99")
+
+	//verify
+	require.Error(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "refused", entries[0].Status)
+	require.Equal(t, "99", entries[0].Params.Get("id"))
+	require.Equal(t, err.Error(), entries[0].Error)
+	require.Equal(t, []string{"voipms audit failed"}, logged)
+}
+
+func TestOpenAuditLog(t *testing.T) {
+
+	//setup
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	entry := AuditEntry{Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), Actor: "jane", Method: "setDIDRouting", Status: "success"}
+
+	//execute
+	sink, err := OpenAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Record(entry))
+	require.NoError(t, sink.Close())
+
+	sink, err = OpenAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Record(entry))
+	require.NoError(t, sink.Close())
+
+	//verify
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		lines = append(lines, e)
+	}
+	require.Len(t, lines, 2)
+	require.Equal(t, "jane", lines[1].Actor)
+	require.Equal(t, "setDIDRouting", lines[1].Method)
+	require.True(t, entry.Time.Equal(lines[1].Time))
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+//Returned without sending anything while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("voipms: circuit breaker is open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+//Fails fast after Threshold consecutive transient failures. Once Cooldown has passed a single trial request is let
+//through; success closes the breaker again and failure re-opens it. API level errors like invalid_did don't count as
+//failures as voip.ms is clearly up when it sends them. Safe to share between clients.
+type CircuitBreaker struct {
+	Threshold     int
+	Cooldown      time.Duration
+	OnStateChange func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+	}
+}
+
+//Fails fast with breaker while it is open.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *VOIPClient) {
+		c.breaker = breaker
+	}
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+//Returns ErrCircuitOpen when a request shouldn't be sent.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	from := b.state
+	err := b.allow()
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return err
+}
+
+func (b *CircuitBreaker) allow() error {
+	switch b.state {
+	case BreakerOpen:
+		if b.clock().Sub(b.openedAt) < b.Cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+
+	return nil
+}
+
+//Records the outcome of a request let through by Allow. Failures caused by the caller giving up, i.e. its context
+//being cancelled, say nothing about voip.ms and shouldn't be recorded.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	from := b.state
+	b.record(err)
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.trial = false
+
+	if err == nil || !isTransient(err) {
+		b.failures = 0
+		b.state = BreakerClosed
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.Threshold) {
+		b.openedAt = b.clock()
+		b.state = BreakerOpen
+	}
+}
+
+//Frees the trial slot taken by Allow without recording an outcome.
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+//Hooks are called outside the lock so they can safely call back into the breaker.
+func (b *CircuitBreaker) changed(from, to BreakerState) {
+	if b.OnStateChange != nil && from != to {
+		b.OnStateChange(from, to)
+	}
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
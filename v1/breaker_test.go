@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_States(t *testing.T) {
+
+	//setup
+	now := time.Now()
+	var changes []BreakerState
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	b.OnStateChange = func(from, to BreakerState) {
+		changes = append(changes, to)
+	}
+	transient := newAPIError("getIP", "", http.StatusBadGateway)
+
+	//execute & verify
+	require.NoError(t, b.Allow())
+	b.Record(transient)
+	require.Equal(t, BreakerClosed, b.State())
+
+	require.NoError(t, b.Allow())
+	b.Record(transient)
+	require.Equal(t, BreakerOpen, b.State())
+	require.Equal(t, ErrCircuitOpen, b.Allow())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.Allow())
+	require.Equal(t, BreakerHalfOpen, b.State())
+	require.Equal(t, ErrCircuitOpen, b.Allow()) //only one trial at a time
+
+	b.Record(transient)
+	require.Equal(t, BreakerOpen, b.State())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.Allow())
+	b.Record(newAPIError("getIP", "invalid_did", http.StatusOK)) //voip.ms answered
+	require.Equal(t, BreakerClosed, b.State())
+
+	require.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen, BreakerHalfOpen, BreakerClosed}, changes)
+}
+
+func TestCircuitBreaker_FailsFast(t *testing.T) {
+
+	//setup
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "", WithCircuitBreaker(NewCircuitBreaker(1, time.Hour))).NewGeneralAPI()
+
+	//execute
+	_, err1 := api.GetIP()
+	_, err2 := api.GetIP()
+
+	//verify
+	require.True(t, errors.Is(err1, ErrUnavailable))
+	require.Equal(t, ErrCircuitOpen, err2)
+	require.Equal(t, 1, calls)
+}
+
+func TestCircuitBreaker_Timeouts(t *testing.T) {
+
+	//setup
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	breaker := NewCircuitBreaker(1, time.Hour)
+	api := NewVOIPClient(ts.URL, "", "", WithCircuitBreaker(breaker), WithTimeout(10*time.Millisecond)).NewGeneralAPI()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//execute & verify
+	_, err := api.GetIPContext(ctx)
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, BreakerClosed, breaker.State()) //the caller gave up
+
+	_, err = api.GetIP()
+	require.Error(t, err)
+	require.Equal(t, BreakerOpen, breaker.State()) //voip.ms hung
+}
+
+//Waits for ctx unless open.
+type gateLimiter struct {
+	open bool
+}
+
+func (l *gateLimiter) Wait(ctx context.Context, method string) error {
+	if !l.open {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func TestCircuitBreaker_LimiterTimeout(t *testing.T) {
+
+	//setup
+	fail := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"status":"success","ip":"127.0.0.1"}`))
+	}))
+	defer ts.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	limiter := &gateLimiter{open: true}
+	api := NewVOIPClient(ts.URL, "", "", WithCircuitBreaker(breaker), WithRateLimiter(limiter)).NewGeneralAPI()
+
+	_, err := api.GetIP()
+	require.True(t, errors.Is(err, ErrUnavailable))
+	now = now.Add(time.Minute)
+
+	//execute, the trial times out waiting on the limiter
+	limiter.open = false
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, timeoutErr := api.GetIPContext(ctx)
+
+	limiter.open = true
+	fail = false
+	ip, err := api.GetIP()
+
+	//verify
+	require.True(t, errors.Is(timeoutErr, context.DeadlineExceeded))
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ip)
+	require.Equal(t, BreakerClosed, breaker.State())
+}
@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+//How long responses to near constant lookups are kept by a Cache unless changed with SetTTL.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"getCountries":       24 * time.Hour,
+	"getLanguages":       24 * time.Hour,
+	"getServersInfo":     24 * time.Hour,
+	"getAllowedCodecs":   24 * time.Hour,
+	"getDeviceTypes":     24 * time.Hour,
+	"getDTMFModes":       24 * time.Hour,
+	"getNAT":             24 * time.Hour,
+	"getRoutes":          24 * time.Hour,
+	"getProvinces":       24 * time.Hour,
+	"getStates":          24 * time.Hour,
+	"getCarriers":        24 * time.Hour,
+	"getVoicemailSetups": 24 * time.Hour,
+}
+
+//Storage behind a Cache, i.e. memory or a shared Redis. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+}
+
+//Caches successful responses to the methods it has a TTL for. Concurrent misses for the same call are sent to voip.ms
+//once and share the response. Share one between clients to share the cached responses, responses are only shared
+//between clients with the same username.
+type Cache struct {
+	store CacheStore
+
+	mu          sync.Mutex
+	ttls        map[string]time.Duration
+	generation  uint64            //Bumped by InvalidateAll.
+	generations map[string]uint64 //Bumped by Invalidate.
+	calls       map[string]*cacheCall
+}
+
+//A miss being sent to voip.ms that other callers are waiting on.
+type cacheCall struct {
+	done       chan struct{}
+	httpStatus int
+	status     string
+	response   []byte
+	err        error
+}
+
+//A nil store uses a MemoryStore. TTLs start out as DefaultCacheTTLs.
+func NewCache(store CacheStore) *Cache {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+
+	c := &Cache{
+		store:       store,
+		ttls:        map[string]time.Duration{},
+		generations: map[string]uint64{},
+		calls:       map[string]*cacheCall{},
+	}
+	for method, ttl := range DefaultCacheTTLs {
+		c.ttls[method] = ttl
+	}
+	return c
+}
+
+//Answers calls from cache when it can. Middleware still sees cache hits, with a Latency of 0.
+func WithCache(cache *Cache) Option {
+	return func(c *VOIPClient) {
+		c.cache = cache
+	}
+}
+
+//Caches method, i.e. getRateCentersUSA, for ttl. A ttl of 0 or less stops caching it.
+func (c *Cache) SetTTL(method string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		delete(c.ttls, method)
+		return
+	}
+	c.ttls[method] = ttl
+}
+
+//Drops every cached response to methods. Calls already in flight aren't cached.
+func (c *Cache) Invalidate(methods ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, method := range methods {
+		c.generations[method]++
+	}
+}
+
+//Drops every cached response.
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+}
+
+//Keys include the generations so invalidating never has to find the old entries, they just stop being read and expire.
+//The username keeps one account's responses from being served to another.
+func (c *Cache) key(username string, inv *Invocation) string {
+	params := url.Values{}
+	for k, v := range inv.params {
+		params[k] = v
+	}
+	params.Set("api_username", username)
+	return fmt.Sprintf("voipms/%d/%d/%s?%s", c.generation, c.generations[inv.Method], inv.Method, params.Encode())
+}
+
+func (c *Cache) wrap(username string, next Handler) Handler {
+	return func(ctx context.Context, inv *Invocation) error {
+		c.mu.Lock()
+		ttl := c.ttls[inv.Method]
+		key := c.key(username, inv)
+		c.mu.Unlock()
+
+		if ttl <= 0 {
+			return next(ctx, inv)
+		}
+
+		for {
+			if b, ok := c.store.Get(key); ok {
+				inv.HTTPStatus = 200
+				inv.Status = "success"
+				inv.Response = append([]byte(nil), b...)
+				return nil
+			}
+
+			c.mu.Lock()
+			if call, ok := c.calls[key]; ok {
+				c.mu.Unlock()
+
+				select {
+				case <-call.done:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+
+				//The caller sending the request gave up, so try again with this context.
+				if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+					continue
+				}
+
+				inv.HTTPStatus = call.httpStatus
+				inv.Status = call.status
+				inv.Response = append([]byte(nil), call.response...)
+				return call.err
+			}
+
+			call := &cacheCall{done: make(chan struct{})}
+			c.calls[key] = call
+			c.mu.Unlock()
+
+			err := next(ctx, inv)
+			if err == nil {
+				c.store.Set(key, append([]byte(nil), inv.Response...), ttl)
+			}
+
+			call.httpStatus = inv.HTTPStatus
+			call.status = inv.Status
+			call.response = inv.Response
+			call.err = err
+
+			c.mu.Lock()
+			delete(c.calls, key)
+			c.mu.Unlock()
+			close(call.done)
+
+			return err
+		}
+	}
+}
+
+//In process CacheStore. Expired entries are dropped as they're read and swept out every minute on Set.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	swept   time.Time
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: map[string]memoryEntry{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(e.expires) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (s *MemoryStore) Set(key string, value []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.swept) >= time.Minute {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.swept = now
+	}
+
+	s.entries[key] = memoryEntry{value, now.Add(ttl)}
+}
+
+func (s *MemoryStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
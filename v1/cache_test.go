@@ -0,0 +1,261 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func countriesServer(calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		switch formValues(r).Get("method") {
+		case "getCountries":
+			fmt.Fprintf(w, `{"status":"success","countries":[{"value":"%s","description":"Canada"}]}`, formValues(r).Get("country"))
+		case "getLanguages":
+			fmt.Fprintln(w, `{"status":"invalid_language"}`)
+		default:
+			fmt.Fprintln(w, `{"status":"success","ip":"10.0.0.1"}`)
+		}
+	}))
+}
+
+func TestCache_Hits(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := countriesServer(&calls)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "", WithCache(NewCache(nil))).NewGeneralAPI()
+
+	//execute
+	first, err1 := api.GetCountries("CA")
+	second, err2 := api.GetCountries("CA")
+	other, err3 := api.GetCountries("US")
+
+	//verify
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	require.NoError(t, err3)
+	require.Equal(t, first, second)
+	require.Equal(t, "US", other[0].Value)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestCache_Shared(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := countriesServer(&calls)
+	defer ts.Close()
+
+	cache := NewCache(nil)
+	first := NewVOIPClient(ts.URL, "first@example.com", "", WithCache(cache)).NewGeneralAPI()
+	second := NewVOIPClient(ts.URL, "second@example.com", "", WithCache(cache)).NewGeneralAPI()
+	again := NewVOIPClient(ts.URL, "first@example.com", "", WithCache(cache)).NewGeneralAPI()
+
+	//execute
+	_, err1 := first.GetCountries("CA")
+	_, err2 := second.GetCountries("CA")
+	_, err3 := again.GetCountries("CA")
+
+	//verify
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	require.NoError(t, err3)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestCache_ServerInfo(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			fmt.Fprintln(w, `{"status":"error"}`)
+			return
+		}
+		require.Equal(t, "getServersInfo", formValues(r).Get("method"))
+		fmt.Fprintln(w, `{"status":"success","servers":[{"server_name":"Montreal","server_pop":"3"}]}`)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "", WithCache(NewCache(nil))).NewGeneralAPI()
+
+	//execute
+	first, err1 := api.GetServerInfo("")
+	second, err2 := api.GetServerInfo("")
+
+	//verify
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	require.Equal(t, first, second)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+//A misspelt method name is never sent, so it's never cached either.
+func TestDefaultCacheTTLs_KnownMethods(t *testing.T) {
+	for method := range DefaultCacheTTLs {
+		_, ok := LookupMethod(method)
+		require.True(t, ok, method)
+	}
+}
+
+func TestCache_SkipsErrorsAndUncachedMethods(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := countriesServer(&calls)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "", WithCache(NewCache(nil))).NewGeneralAPI()
+
+	//execute
+	_, err1 := api.GetLanguages("")
+	_, err2 := api.GetLanguages("")
+	api.GetIP()
+	api.GetIP()
+
+	//verify
+	require.EqualError(t, err1, "invalid_language")
+	require.EqualError(t, err2, "invalid_language")
+	require.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestCache_Invalidate(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := countriesServer(&calls)
+	defer ts.Close()
+
+	cache := NewCache(nil)
+	api := NewVOIPClient(ts.URL, "", "", WithCache(cache)).NewGeneralAPI()
+	api.GetCountries("CA")
+
+	//execute & verify
+	cache.Invalidate("getLanguages")
+	api.GetCountries("CA")
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	cache.Invalidate("getCountries")
+	api.GetCountries("CA")
+	api.GetCountries("CA")
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	cache.InvalidateAll()
+	api.GetCountries("CA")
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	cache.SetTTL("getCountries", 0)
+	api.GetCountries("CA")
+	require.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestCache_Expires(t *testing.T) {
+
+	//setup
+	var calls int32
+	ts := countriesServer(&calls)
+	defer ts.Close()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	cache := NewCache(store)
+	cache.SetTTL("getCountries", time.Minute)
+	api := NewVOIPClient(ts.URL, "", "", WithCache(cache)).NewGeneralAPI()
+
+	//execute & verify
+	api.GetCountries("CA")
+	now = now.Add(59 * time.Second)
+	api.GetCountries("CA")
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	now = now.Add(time.Second)
+	api.GetCountries("CA")
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	require.Equal(t, 1, store.Len())
+}
+
+func TestCache_SingleFlight(t *testing.T) {
+
+	//setup
+	var calls int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fmt.Fprintln(w, `{"status":"success","countries":[{"value":"CA","description":"Canada"}]}`)
+	}))
+	defer ts.Close()
+
+	var hits int32
+	count := func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) error {
+			atomic.AddInt32(&hits, 1)
+			return next(ctx, inv)
+		}
+	}
+	api := NewVOIPClient(ts.URL, "", "", WithCache(NewCache(nil)), WithMiddleware(count)).NewGeneralAPI()
+
+	//execute
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			countries, err := api.GetCountries("CA")
+			if err == nil && len(countries) != 1 {
+				err = fmt.Errorf("got %d countries", len(countries))
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	//verify
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	require.Equal(t, int32(5), atomic.LoadInt32(&hits))
+}
+
+func TestCache_WaiterContext(t *testing.T) {
+
+	//setup
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprintln(w, `{"status":"success","countries":[]}`)
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	api := NewVOIPClient(ts.URL, "", "", WithCache(NewCache(nil))).NewGeneralAPI()
+	go api.GetCountries("CA")
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	//execute
+	_, err := api.GetCountriesContext(ctx, "CA")
+
+	//verify
+	require.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,129 @@
+//Package cassette records voip.ms method calls made through a VOIPClient into fixture files and replays them, so
+//integrations can be tested offline against realistic payloads.
+//
+//Record once against the real API:
+//
+//	rec := cassette.NewRecorder(nil)
+//	c := v1.NewVOIPClient(url, username, password, v1.WithTransport(rec))
+//	... make calls ...
+//	rec.Cassette().Save("testdata/dids.json")
+//
+//Then replay in tests:
+//
+//	cas, _ := cassette.Load("testdata/dids.json")
+//	c := v1.NewVOIPClient("http://voip.ms.invalid", "", "", v1.WithTransport(cassette.NewReplayer(cas)))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Credentials are dropped from recorded parameters entirely, other secrets are redacted.
+var credentials = map[string]bool{
+	"api_username": true,
+	"api_password": true,
+}
+
+//One recorded method call.
+type Interaction struct {
+	Method     string          `json:"method"`
+	Params     url.Values      `json:"params"`
+	HTTPStatus int             `json:"http_status"`
+	Response   json.RawMessage `json:"response,omitempty"` //Set when the response body is JSON.
+	Body       string          `json:"body,omitempty"`     //Set when it isn't, i.e. error pages.
+}
+
+type Cassette struct {
+	mu           sync.Mutex
+	Interactions []Interaction `json:"interactions"`
+}
+
+func Load(path string) (*Cassette, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cassette{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	b, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), os.FileMode(0644))
+}
+
+func (c *Cassette) add(i Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, i)
+}
+
+//Returns a copy of params without credentials and with secrets redacted.
+func scrubParams(params url.Values) url.Values {
+	scrubbed := url.Values{}
+	for k, v := range params {
+		if credentials[k] {
+			continue
+		}
+		scrubbed[k] = v
+	}
+	return v1.RedactValues(scrubbed)
+}
+
+func newInteraction(params url.Values, httpStatus int, body []byte) Interaction {
+	i := Interaction{
+		Method:     params.Get("method"),
+		Params:     scrubParams(params),
+		HTTPStatus: httpStatus,
+	}
+	i.Params.Del("method")
+
+	body = bytes.TrimSpace(body)
+	if json.Valid(body) {
+		i.Response = v1.RedactJSON(body)
+	} else {
+		i.Body = string(body)
+	}
+
+	return i
+}
+
+//Identifies an interaction by method and parameters, minus any the replayer was told to ignore.
+func key(method string, params url.Values, ignore map[string]bool) string {
+	names := make([]string, 0, len(params))
+	for k := range params {
+		if !ignore[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	parts := []string{method}
+	for _, k := range names {
+		values := append([]string(nil), params[k]...)
+		sort.Strings(values)
+		parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(strings.Join(values, ",")))
+	}
+
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,127 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("method") {
+		case "getSubAccounts":
+			fmt.Fprintln(w, `{"status":"success","accounts":[{"account":"100000_a","password":"SubPass1"}]}`)
+		case "delSubAccount":
+			fmt.Fprintln(w, `{"status":"invalid_account"}`)
+		}
+	}))
+
+	rec := NewRecorder(nil)
+	c := v1.NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", v1.WithTransport(rec))
+
+	accounts, err := c.NewAccountsAPI().GetSubAccounts("100000_a")
+	require.NoError(t, err)
+	require.Equal(t, "SubPass1", accounts[0].Password)
+	require.Error(t, c.NewAccountsAPI().DelSubAccount("1"))
+	ts.Close()
+
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	//execute
+	require.NoError(t, rec.Cassette().Save(path))
+	cas, err := Load(path)
+	require.NoError(t, err)
+
+	replay := v1.NewVOIPClient("http://voip.ms.invalid", "other@example.com", "Other1", v1.WithTransport(NewReplayer(cas)))
+	replayed, err := replay.NewAccountsAPI().GetSubAccounts("100000_a")
+	replayErr := replay.NewAccountsAPI().DelSubAccount("1")
+
+	//verify
+	b, _ := ioutil.ReadFile(path)
+	require.NotContains(t, string(b), "me@example.com")
+	require.NotContains(t, string(b), "ApiPass1")
+	require.NotContains(t, string(b), "SubPass1")
+
+	require.Len(t, cas.Interactions, 2)
+	require.Equal(t, "getSubAccounts", cas.Interactions[0].Method)
+	require.Equal(t, "100000_a", cas.Interactions[0].Params.Get("account"))
+
+	require.NoError(t, err)
+	require.Equal(t, "100000_a", replayed[0].Account)
+	require.Equal(t, v1.Redacted, replayed[0].Password)
+	require.EqualError(t, replayErr, "invalid_account")
+}
+
+func TestReplayer_Fixture(t *testing.T) {
+
+	//setup
+	cas, err := Load("testdata/general.json")
+	require.NoError(t, err)
+
+	general := v1.NewVOIPClient("http://voip.ms.invalid", "", "", v1.WithTransport(NewReplayer(cas))).NewGeneralAPI()
+
+	//execute
+	balance, errBalance := general.GetBalance(true)
+	ip, errIP := general.GetIP()
+	_, errLanguages := general.GetLanguages("")
+	_, errCountries := general.GetCountries("")
+
+	//verify
+	require.NoError(t, errBalance)
+	require.Equal(t, "27.1462", balance.CurrentBalance.String())
+	require.Equal(t, 4*time.Hour+35*time.Minute+49*time.Second, balance.TimeTotal.Duration())
+	require.NoError(t, errIP)
+	require.Equal(t, "203.0.113.10", ip)
+	require.True(t, errors.Is(errLanguages, v1.ErrUnavailable))
+
+	var noInteraction *NoInteractionError
+	require.True(t, errors.As(errCountries, &noInteraction))
+	require.Equal(t, "getCountries", noInteraction.Method)
+}
+
+func TestReplayer_Sequence(t *testing.T) {
+
+	//setup
+	cas := &Cassette{Interactions: []Interaction{
+		{Method: "getIP", HTTPStatus: 200, Response: []byte(`{"status":"success","ip":"10.0.0.1"}`)},
+		{Method: "getIP", HTTPStatus: 200, Response: []byte(`{"status":"success","ip":"10.0.0.2"}`)},
+	}}
+
+	general := v1.NewVOIPClient("http://voip.ms.invalid", "", "", v1.WithTransport(NewReplayer(cas))).NewGeneralAPI()
+
+	//execute
+	ip1, _ := general.GetIP()
+	ip2, _ := general.GetIP()
+	ip3, _ := general.GetIP()
+
+	//verify
+	require.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.2"}, []string{ip1, ip2, ip3})
+}
+
+func TestReplayer_Ignore(t *testing.T) {
+
+	//setup
+	cas := &Cassette{Interactions: []Interaction{
+		{Method: "getCountries", Params: map[string][]string{"country": {"CA"}}, HTTPStatus: 200, Response: []byte(`{"status":"success","countries":[{"value":"CA","description":"Canada"}]}`)},
+	}}
+
+	general := v1.NewVOIPClient("http://voip.ms.invalid", "", "", v1.WithTransport(NewReplayer(cas, "country"))).NewGeneralAPI()
+
+	//execute
+	countries, err := general.GetCountries("US")
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "Canada", countries[0].Description)
+}
@@ -0,0 +1,58 @@
+package cassette
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//http.RoundTripper that forwards requests to voip.ms and records each exchange with credentials scrubbed.
+type Recorder struct {
+	transport http.RoundTripper
+	cassette  *Cassette
+
+	//Called on every interaction before it's recorded to scrub anything else, i.e. customer names or numbers.
+	Scrub func(*Interaction)
+}
+
+//A nil transport uses http.DefaultTransport.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{
+		transport: transport,
+		cassette:  &Cassette{},
+	}
+}
+
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := v1.RequestParams(req)
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	i := newInteraction(params, resp.StatusCode, body)
+	if r.Scrub != nil {
+		r.Scrub(&i)
+	}
+	r.cassette.add(i)
+
+	return resp, nil
+}
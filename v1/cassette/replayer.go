@@ -0,0 +1,94 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Returned by Replayer when nothing was recorded for a method and its parameters.
+type NoInteractionError struct {
+	Method string
+	Params url.Values
+}
+
+func (e *NoInteractionError) Error() string {
+	return fmt.Sprintf("cassette: no interaction recorded for %s with %s", e.Method, e.Params.Encode())
+}
+
+//http.RoundTripper that answers from a Cassette instead of voip.ms. Interactions are matched on method and
+//parameters, credentials excluded. When the same call was recorded more than once the recordings are served in order,
+//repeating the last one.
+type Replayer struct {
+	mu     sync.Mutex
+	served map[string]int
+	byKey  map[string][]Interaction
+	ignore map[string]bool
+}
+
+//Parameters named in ignore don't take part in matching, i.e. dates that change on every run.
+func NewReplayer(c *Cassette, ignore ...string) *Replayer {
+	r := &Replayer{
+		served: map[string]int{},
+		byKey:  map[string][]Interaction{},
+		ignore: map[string]bool{},
+	}
+
+	for _, name := range ignore {
+		r.ignore[name] = true
+	}
+
+	for _, i := range c.Interactions {
+		k := key(i.Method, i.Params, r.ignore)
+		r.byKey[k] = append(r.byKey[k], i)
+	}
+
+	return r
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := v1.RequestParams(req)
+	method := params.Get("method")
+	scrubbed := scrubParams(params)
+	scrubbed.Del("method")
+
+	k := key(method, scrubbed, r.ignore)
+
+	r.mu.Lock()
+	recorded := r.byKey[k]
+	n := r.served[k]
+	r.served[k]++
+	r.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, &NoInteractionError{method, scrubbed}
+	}
+	if n >= len(recorded) {
+		n = len(recorded) - 1
+	}
+	i := recorded[n]
+
+	body := []byte(i.Body)
+	contentType := "text/html; charset=utf-8"
+	if len(i.Response) > 0 {
+		body = i.Response
+		contentType = "application/json"
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.HTTPStatus, http.StatusText(i.HTTPStatus)),
+		StatusCode:    i.HTTPStatus,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,321 @@
+package v1
+
+import (
+	"sort"
+	"strings"
+)
+
+//Groups voip.ms methods the way the API documentation does.
+type Category string
+
+const (
+	CategoryGeneral    Category = "General"
+	CategoryAccounts   Category = "Accounts"
+	CategoryCDR        Category = "CDR"
+	CategoryClients    Category = "Clients"
+	CategoryDIDs       Category = "DIDs"
+	CategoryE911       Category = "E911"
+	CategoryFax        Category = "Fax"
+	CategoryLNP        Category = "LNP"
+	CategoryVoicemail  Category = "Voicemail"
+	CategoryConference Category = "Conference"
+)
+
+//How much of a voip.ms method this package covers.
+type MethodStatus string
+
+const (
+	StatusImplemented MethodStatus = "implemented" //Has a typed function, see MethodInfo.Func.
+	StatusStubbed     MethodStatus = "stubbed"     //MethodInfo.Func exists but only returns an error for now. Use Do.
+	StatusMissing     MethodStatus = "missing"     //Nothing but Do.
+)
+
+//Describes one voip.ms API method.
+type MethodInfo struct {
+	Name         string //voip.ms method name, i.e. orderDID.
+	Category     Category
+	Mutates      bool         //Changes the account, orders, charges or sends something.
+	SupportsTest bool         //Accepts test=true to validate without doing anything.
+	Required     []string     //Parameters voip.ms rejects the call without, credentials aside.
+	Func         string       //Go function implementing it, i.e. DIDsAPI.OrderDID. Empty when there isn't one.
+	Status       MethodStatus //Worked out from Func unless given.
+}
+
+//Every method in the voip.ms API documentation, keyed by name. Mutates and SupportsTest drive WithDryRun and
+//WithReadOnly, so check both when adding one.
+var catalog = map[string]MethodInfo{}
+
+func init() {
+	for _, m := range []MethodInfo{
+		//General
+		{"getBalance", CategoryGeneral, false, false, nil, "GeneralAPI.GetBalance", ""},
+		{"getCountries", CategoryGeneral, false, false, nil, "GeneralAPI.GetCountries", ""},
+		{"getIP", CategoryGeneral, false, false, nil, "GeneralAPI.GetIP", ""},
+		{"getLanguages", CategoryGeneral, false, false, nil, "GeneralAPI.GetLanguages", ""},
+		{"getLocales", CategoryGeneral, false, false, nil, "", ""},
+		{"getServersInfo", CategoryGeneral, false, false, nil, "GeneralAPI.GetServerInfo", ""},
+		{"getTransactionHistory", CategoryGeneral, false, false, []string{"date_from", "date_to"}, "GeneralAPI.GetTransactionHistory", ""},
+
+		//Accounts
+		{"createSubAccount", CategoryAccounts, true, false, []string{"username", "protocol", "description", "auth_type", "password", "ip", "device_type", "callerid_number", "canada_routing", "lock_international", "international_route", "music_on_hold", "allowed_codecs", "dtmf_mode", "nat"}, "AccountsAPI.CreateSubAccount", ""},
+		{"delSubAccount", CategoryAccounts, true, false, []string{"id"}, "AccountsAPI.DelSubAccount", ""},
+		{"getAllowedCodecs", CategoryAccounts, false, false, nil, "AccountsAPI.GetAllowedCodecs", ""},
+		{"getAuthTypes", CategoryAccounts, false, false, nil, "AccountsAPI.GetAuthTypes", ""},
+		{"getDeviceTypes", CategoryAccounts, false, false, nil, "AccountsAPI.GetDeviceTypes", ""},
+		{"getDTMFModes", CategoryAccounts, false, false, nil, "AccountsAPI.GetDTMFModes", ""},
+		{"getInvoice", CategoryAccounts, false, false, []string{"from", "to"}, "", ""},
+		{"getLockInternational", CategoryAccounts, false, false, nil, "AccountsAPI.GetLockInternational", ""},
+		{"getMusicOnHold", CategoryAccounts, false, false, nil, "AccountsAPI.GetMusicOnHold", ""},
+		{"getNAT", CategoryAccounts, false, false, nil, "AccountsAPI.GetNAT", ""},
+		{"getProtocols", CategoryAccounts, false, false, nil, "AccountsAPI.GetProtocols", ""},
+		{"getRegistrationStatus", CategoryAccounts, false, false, []string{"account"}, "AccountsAPI.GetRegistrationStatus", ""},
+		{"getReportEstimatedHoldTime", CategoryAccounts, false, false, nil, "AccountsAPI.GetReportEstimatedHoldTime", ""},
+		{"getRoutes", CategoryAccounts, false, false, nil, "AccountsAPI.GetRoutes", ""},
+		{"getSubAccounts", CategoryAccounts, false, false, nil, "AccountsAPI.GetSubAccounts", ""},
+		{"setSubAccount", CategoryAccounts, true, false, []string{"id", "description", "auth_type", "password", "ip", "device_type", "callerid_number", "canada_routing", "lock_international", "international_route", "music_on_hold", "allowed_codecs", "dtmf_mode", "nat"}, "AccountsAPI.SetSubAccount", ""},
+
+		//CDR
+		{"getCallAccounts", CategoryCDR, false, false, []string{"client"}, "CDRAPI.GetCallAccounts", ""},
+		{"getCallBilling", CategoryCDR, false, false, nil, "CDRAPI.GetCallBilling", ""},
+		{"getCallTypes", CategoryCDR, false, false, []string{"client"}, "CDRAPI.GetCallTypes", ""},
+		{"getCDR", CategoryCDR, false, false, []string{"date_from", "date_to", "timezone"}, "CDRAPI.GetCDR", ""},
+		{"getRates", CategoryCDR, false, false, []string{"package", "query"}, "CDRAPI.GetRates", ""},
+		{"getTerminationRates", CategoryCDR, false, false, []string{"route", "query"}, "CDRAPI.GetTerminationRates", ""},
+		{"getResellerCDR", CategoryCDR, false, false, []string{"date_from", "date_to", "client", "timezone"}, "CDRAPI.GetResellerCDR", ""},
+		{"getCallRecordings", CategoryCDR, false, false, []string{"account"}, "", ""},
+		{"getCallRecording", CategoryCDR, false, false, []string{"account", "callrecording"}, "", ""},
+		{"sendCallRecordingEmail", CategoryCDR, true, false, []string{"account", "callrecording", "email"}, "", ""},
+		{"delCallRecording", CategoryCDR, true, false, []string{"account", "callrecording"}, "", ""},
+
+		//Clients
+		{"addCharge", CategoryClients, true, true, []string{"client", "charge"}, "ClientsAPI.AddCharge", ""},
+		{"addPayment", CategoryClients, true, true, []string{"client", "payment"}, "ClientsAPI.AddPayment", ""},
+		{"assignDIDvPRI", CategoryClients, true, false, []string{"did", "vpri"}, "", ""},
+		{"delClient", CategoryClients, true, false, []string{"client"}, "DIDsAPI.DelClient", ""},
+		{"getBalanceManagement", CategoryClients, false, false, nil, "ClientsAPI.GetBalanceManagement", ""},
+		{"getCharges", CategoryClients, false, false, []string{"client"}, "ClientsAPI.GetCharges", ""},
+		{"getClientPackages", CategoryClients, false, false, []string{"client"}, "ClientsAPI.GetClientPackages", ""},
+		{"getClients", CategoryClients, false, false, nil, "ClientsAPI.GetClients", ""},
+		{"getClientThreshold", CategoryClients, false, false, []string{"client"}, "ClientsAPI.GetClientThreshold", ""},
+		{"getDeposits", CategoryClients, false, false, []string{"client"}, "ClientsAPI.GetDeposits", ""},
+		{"getPackages", CategoryClients, false, false, nil, "ClientsAPI.GetPackages", ""},
+		{"getResellerBalance", CategoryClients, false, false, []string{"client"}, "ClientsAPI.GetResellerBalance", ""},
+		{"setBalanceManagement", CategoryClients, true, false, []string{"client", "balance_management"}, "", ""},
+		{"setClient", CategoryClients, true, false, []string{"client", "email", "password", "firstname", "lastname", "phone_number"}, "ClientsAPI.SetClient", ""},
+		{"setClientThreshold", CategoryClients, true, false, []string{"client", "threshold"}, "ClientsAPI.SetClientThreshold", ""},
+		{"signupClient", CategoryClients, true, false, []string{"firstname", "lastname", "address", "city", "state", "country", "zip", "phone_number", "email", "confirm_email", "password", "confirm_password"}, "ClientsAPI.SignupClient", ""},
+
+		//DIDs
+		{"backOrderDIDUSA", CategoryDIDs, true, true, []string{"quantity", "state", "ratecenter", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.BackOrderDIDUSA", ""},
+		{"backOrderDIDCAN", CategoryDIDs, true, true, []string{"quantity", "province", "ratecenter", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.BackOrderDIDCan", ""},
+		{"cancelDID", CategoryDIDs, true, true, []string{"did"}, "DIDsAPI.CancelDID", ""},
+		{"connectDID", CategoryDIDs, true, false, []string{"did", "account", "monthly", "setup", "minute"}, "DIDsAPI.ConnectDID", ""},
+		{"delCallback", CategoryDIDs, true, false, []string{"callback"}, "DIDsAPI.DelCallback", ""},
+		{"delCallerIDFiltering", CategoryDIDs, true, false, []string{"filtering"}, "DIDsAPI.DelCallerIDFiltering", ""},
+		{"delCallParking", CategoryDIDs, true, false, []string{"callparking"}, "", ""},
+		{"delDISA", CategoryDIDs, true, false, []string{"disa"}, "DIDsAPI.DelDISA", ""},
+		{"deleteSMS", CategoryDIDs, true, false, []string{"id"}, "DIDsAPI.DeleteSMS", ""},
+		{"deleteMMS", CategoryDIDs, true, false, []string{"id"}, "", ""},
+		{"delForwarding", CategoryDIDs, true, false, []string{"forwarding"}, "DIDsAPI.DelForwarding", ""},
+		{"delIVR", CategoryDIDs, true, false, []string{"ivr"}, "DIDsAPI.DelIVR", ""},
+		{"delPhonebook", CategoryDIDs, true, false, []string{"phonebook"}, "DIDsAPI.DelPhonebook", ""},
+		{"delPhonebookGroup", CategoryDIDs, true, false, []string{"group"}, "", ""},
+		{"delQueue", CategoryDIDs, true, false, []string{"queue"}, "DIDsAPI.DelQueue", ""},
+		{"delRecording", CategoryDIDs, true, false, []string{"recording"}, "DIDsAPI.DelRecording", ""},
+		{"delRingGroup", CategoryDIDs, true, false, []string{"ringgroup"}, "DIDsAPI.DelRingGroup", ""},
+		{"delSIPURI", CategoryDIDs, true, false, []string{"sipuri"}, "DIDsAPI.DelSIPURI", ""},
+		{"delStaticMember", CategoryDIDs, true, false, []string{"member", "queue"}, "DIDsAPI.DelStaticMember", ""},
+		{"delTimeCondition", CategoryDIDs, true, false, []string{"timecondition"}, "DIDsAPI.DelTimeCondition", ""},
+		{"getCallbacks", CategoryDIDs, false, false, nil, "DIDsAPI.GetCallbacks", ""},
+		{"getCallerIDFiltering", CategoryDIDs, false, false, nil, "DIDsAPI.GetCallerIDFiltering", ""},
+		{"getCallParking", CategoryDIDs, false, false, nil, "", ""},
+		{"getCarriers", CategoryDIDs, false, false, nil, "DIDsAPI.GetCarriers", ""},
+		{"getDIDCountries", CategoryDIDs, false, false, []string{"type"}, "DIDsAPI.GetDIDCountries", ""},
+		{"getDIDsCAN", CategoryDIDs, false, false, []string{"province"}, "DIDsAPI.GetDIDsCan", ""},
+		{"getDIDsInfo", CategoryDIDs, false, false, nil, "DIDsAPI.GetDIDsInfo", ""},
+		{"getDIDsInternationalGeographic", CategoryDIDs, false, false, []string{"country_id"}, "DIDsAPI.GetDIDsInternationalGeographic", ""},
+		{"getDIDsInternationalNational", CategoryDIDs, false, false, []string{"country_id"}, "DIDsAPI.GetDIDsInternationalNational", ""},
+		{"getDIDsInternationalTollFree", CategoryDIDs, false, false, []string{"country_id"}, "DIDsAPI.GetDIDsInternationalTollFree", ""},
+		{"getDIDsUSA", CategoryDIDs, false, false, []string{"state"}, "DIDsAPI.GetDIDsUSA", ""},
+		{"getDIDvPRI", CategoryDIDs, false, false, []string{"vpri"}, "", ""},
+		{"getDISAs", CategoryDIDs, false, false, nil, "DIDsAPI.GetDISAs", ""},
+		{"getForwardings", CategoryDIDs, false, false, nil, "DIDsAPI.GetForwardings", ""},
+		{"getInternationalTypes", CategoryDIDs, false, false, nil, "DIDsAPI.GetInternationalTypes", ""},
+		{"getIVRs", CategoryDIDs, false, false, nil, "DIDsAPI.GetIVRs", ""},
+		{"getJoinWhenEmptyTypes", CategoryDIDs, false, false, nil, "DIDsAPI.GetJoinWhenEmptyTypes", ""},
+		{"getMMS", CategoryDIDs, false, false, nil, "", ""},
+		{"getPhonebook", CategoryDIDs, false, false, nil, "DIDsAPI.GetPhonebook", ""},
+		{"getPhonebookGroups", CategoryDIDs, false, false, nil, "", ""},
+		{"getPortability", CategoryDIDs, false, false, []string{"did"}, "DIDsAPI.GetPortability", ""},
+		{"getProvinces", CategoryDIDs, false, false, nil, "DIDsAPI.GetProvinces", ""},
+		{"getQueues", CategoryDIDs, false, false, nil, "DIDsAPI.GetQueues", ""},
+		{"getRateCentersCAN", CategoryDIDs, false, false, []string{"province"}, "DIDsAPI.GetRateCentersCan", ""},
+		{"getRateCentersUSA", CategoryDIDs, false, false, []string{"state"}, "DIDsAPI.GetRateCentersUSA", ""},
+		{"getRecordings", CategoryDIDs, false, false, nil, "DIDsAPI.GetRecordings", StatusStubbed},
+		{"getRecordingFile", CategoryDIDs, false, false, []string{"recording"}, "DIDsAPI.GetRecordingFile", StatusStubbed},
+		{"getRingGroups", CategoryDIDs, false, false, nil, "DIDsAPI.GetRingGroups", StatusStubbed},
+		{"getRingStrategies", CategoryDIDs, false, false, nil, "DIDsAPI.GetRingStrategies", StatusStubbed},
+		{"getSIPURIs", CategoryDIDs, false, false, nil, "DIDsAPI.GetSIPURIs", StatusStubbed},
+		{"getSMS", CategoryDIDs, false, false, nil, "DIDsAPI.GetSMS", StatusStubbed},
+		{"getStates", CategoryDIDs, false, false, nil, "DIDsAPI.GetStates", ""},
+		{"getStaticMembers", CategoryDIDs, false, false, []string{"queue"}, "DIDsAPI.GetStaticMembers", ""},
+		{"getTimeConditions", CategoryDIDs, false, false, nil, "DIDsAPI.GetTimeConditions", ""},
+		{"getVoicemailSetups", CategoryDIDs, false, false, nil, "DIDsAPI.GetVoicemailSetups", ""},
+		{"getVoicemailAttachmentFormats", CategoryDIDs, false, false, nil, "DIDsAPI.GetVoicemailAttachmentFormats", ""},
+		{"orderDID", CategoryDIDs, true, true, []string{"did", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderDID", ""},
+		{"orderDIDInternationalGeographic", CategoryDIDs, true, true, []string{"location_id", "quantity", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderDIDInternationalGeographic", ""},
+		{"orderDIDInternationalNational", CategoryDIDs, true, true, []string{"location_id", "quantity", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderDIDInternationalNational", StatusStubbed},
+		{"orderDIDInternationalTollFree", CategoryDIDs, true, true, []string{"location_id", "quantity", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderDIDInternationalTollFree", StatusStubbed},
+		{"orderDIDVirtual", CategoryDIDs, true, true, []string{"digits", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderDIDVirtual", StatusStubbed},
+		{"orderTollFree", CategoryDIDs, true, true, []string{"did", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.OrderTollFree", StatusStubbed},
+		{"orderVanity", CategoryDIDs, true, true, []string{"did", "routing", "pop", "dialtime", "cnam", "billing_type", "carrier"}, "DIDsAPI.OrderVanity", StatusStubbed},
+		{"removeDIDvPRI", CategoryDIDs, true, false, []string{"did"}, "", ""},
+		{"searchDIDsCAN", CategoryDIDs, false, false, []string{"type", "query"}, "DIDsAPI.SearchDIDsCan", ""},
+		{"searchDIDsUSA", CategoryDIDs, false, false, []string{"type", "query"}, "DIDsAPI.SearchDIDsUSA", StatusStubbed},
+		{"searchTollFreeCanUS", CategoryDIDs, false, false, nil, "DIDsAPI.SearchTollFreeCanUS", StatusStubbed},
+		{"searchTollFreeUSA", CategoryDIDs, false, false, nil, "DIDsAPI.SearchTollFreeUSA", StatusStubbed},
+		{"searchVanity", CategoryDIDs, false, false, []string{"type", "query"}, "DIDsAPI.SearchVanity", StatusStubbed},
+		{"sendMMS", CategoryDIDs, true, false, []string{"did", "dst", "message"}, "", ""},
+		{"sendSMS", CategoryDIDs, true, false, []string{"did", "dst", "message"}, "DIDsAPI.SendSMS", StatusStubbed},
+		{"setCallback", CategoryDIDs, true, false, []string{"description", "number", "delay_before", "response_timeout", "digit_timeout"}, "DIDsAPI.SetCallback", StatusStubbed},
+		{"setCallerIDFiltering", CategoryDIDs, true, false, []string{"callerid", "did", "routing"}, "DIDsAPI.SetCallerIDFiltering", StatusStubbed},
+		{"setCallParking", CategoryDIDs, true, false, []string{"name", "timeout", "music_on_hold", "failover"}, "", ""},
+		{"setDIDBillingType", CategoryDIDs, true, false, []string{"did", "billing_type"}, "DIDsAPI.SetDIDBillingType", StatusStubbed},
+		{"setDIDInfo", CategoryDIDs, true, false, []string{"did", "routing", "pop", "dialtime", "cnam", "billing_type"}, "DIDsAPI.SetDIDInfo", StatusStubbed},
+		{"setDIDPOP", CategoryDIDs, true, false, []string{"did", "pop"}, "DIDsAPI.SetDIDPOP", StatusStubbed},
+		{"setDIDRouting", CategoryDIDs, true, false, []string{"did", "routing"}, "DIDsAPI.SetDIDRouting", StatusStubbed},
+		{"setDIDVoicemail", CategoryDIDs, true, false, []string{"did"}, "DIDsAPI.SetDIDVoicemail", StatusStubbed},
+		{"setDISA", CategoryDIDs, true, false, []string{"name", "pin", "digit_timeout", "callerid_override"}, "DIDsAPI.SetDISA", StatusStubbed},
+		{"setForwarding", CategoryDIDs, true, false, []string{"phone_number"}, "DIDsAPI.SetForwarding", StatusStubbed},
+		{"setIVR", CategoryDIDs, true, false, []string{"name", "recording", "timeout", "language", "voicemailsetup", "choices"}, "DIDsAPI.SetIVR", StatusStubbed},
+		{"setPhonebook", CategoryDIDs, true, false, []string{"name", "number"}, "DIDsAPI.SetPhonebook", StatusStubbed},
+		{"setPhonebookGroup", CategoryDIDs, true, false, []string{"name"}, "", ""},
+		{"setQueue", CategoryDIDs, true, false, []string{"queue_name", "queue_number", "queue_language", "join_when_empty", "leave_when_empty", "ring_inuse"}, "DIDsAPI.SetQueue", StatusStubbed},
+		{"setRecording", CategoryDIDs, true, false, []string{"file", "name"}, "DIDsAPI.SetRecording", StatusStubbed},
+		{"setRingGroup", CategoryDIDs, true, false, []string{"name", "members", "voicemail"}, "DIDsAPI.SetRingGroup", StatusStubbed},
+		{"setSIPURI", CategoryDIDs, true, false, []string{"uri"}, "DIDsAPI.SetSIPURI", StatusStubbed},
+		{"setSMS", CategoryDIDs, true, false, []string{"did", "enable"}, "DIDsAPI.SetSMS", StatusStubbed},
+		{"setStaticMember", CategoryDIDs, true, false, []string{"queue", "member", "priority"}, "DIDsAPI.SetStaticMember", StatusStubbed},
+		{"setTimeCondition", CategoryDIDs, true, false, []string{"name", "routingmatch", "routingnomatch", "starthour", "startminute", "endhour", "endminute", "weekdaystart", "weekdayend"}, "DIDsAPI.SetTimeCondition", StatusStubbed},
+		{"unconnectDID", CategoryDIDs, true, false, []string{"did"}, "DIDsAPI.UnconnectDID", StatusStubbed},
+
+		//E911
+		{"e911AddressTypes", CategoryE911, false, false, nil, "", ""},
+		{"e911Cancel", CategoryE911, true, false, []string{"did"}, "", ""},
+		{"e911Info", CategoryE911, false, false, []string{"did"}, "", ""},
+		{"e911Provision", CategoryE911, true, false, []string{"did", "full_name", "street_number", "street_name", "city", "state", "country", "zip_code", "language"}, "", ""},
+		{"e911ProvisionManually", CategoryE911, true, false, []string{"did", "full_name", "street_number", "street_name", "city", "state", "country", "zip_code", "language"}, "", ""},
+		{"e911Update", CategoryE911, true, false, []string{"did", "full_name", "street_number", "street_name", "city", "state", "country", "zip_code", "language"}, "", ""},
+		{"e911Validate", CategoryE911, false, false, []string{"did", "street_number", "street_name", "city", "state", "country", "zip_code"}, "", ""},
+
+		//Fax
+		{"cancelFaxNumber", CategoryFax, true, true, []string{"id"}, "", ""},
+		{"delEmailToFax", CategoryFax, true, false, []string{"id"}, "", ""},
+		{"deleteFaxMessage", CategoryFax, true, false, []string{"id"}, "", ""},
+		{"delFaxFolder", CategoryFax, true, false, []string{"id"}, "", ""},
+		{"getEmailToFax", CategoryFax, false, false, nil, "", ""},
+		{"getFaxFolders", CategoryFax, false, false, nil, "", ""},
+		{"getFaxMessagePDF", CategoryFax, false, false, []string{"id"}, "", ""},
+		{"getFaxMessages", CategoryFax, false, false, nil, "", ""},
+		{"getFaxNumbersInfo", CategoryFax, false, false, nil, "", ""},
+		{"getFaxNumbersPortability", CategoryFax, false, false, []string{"did"}, "", ""},
+		{"getFaxProvinces", CategoryFax, false, false, nil, "", ""},
+		{"getFaxRateCentersCAN", CategoryFax, false, false, []string{"province"}, "", ""},
+		{"getFaxRateCentersUSA", CategoryFax, false, false, []string{"state"}, "", ""},
+		{"getFaxStates", CategoryFax, false, false, nil, "", ""},
+		{"mailFaxMessagePDF", CategoryFax, true, false, []string{"id", "email"}, "", ""},
+		{"moveFaxMessage", CategoryFax, true, false, []string{"fax_id", "folder_id"}, "", ""},
+		{"orderFaxNumber", CategoryFax, true, true, []string{"location", "quantity"}, "", ""},
+		{"searchFaxAreaCodeCAN", CategoryFax, false, false, []string{"area_code"}, "", ""},
+		{"searchFaxAreaCodeUSA", CategoryFax, false, false, []string{"area_code"}, "", ""},
+		{"sendFaxMessage", CategoryFax, true, true, []string{"to_number", "from_name", "from_number", "file"}, "", ""},
+		{"setEmailToFax", CategoryFax, true, false, []string{"auth_email", "from_number_id", "security_code"}, "", ""},
+		{"setFaxFolder", CategoryFax, true, false, []string{"name"}, "", ""},
+		{"setFaxNumberEmail", CategoryFax, true, false, []string{"did"}, "", ""},
+		{"setFaxNumberInfo", CategoryFax, true, false, []string{"did"}, "", ""},
+		{"setFaxNumberURLCallback", CategoryFax, true, false, []string{"did"}, "", ""},
+
+		//LNP
+		{"addLNPFile", CategoryLNP, true, false, []string{"portid", "file"}, "", ""},
+		{"addLNPPort", CategoryLNP, true, false, []string{"numbers", "location_type", "business_name", "first_name", "last_name", "address1", "city", "state", "zip", "country", "provider_name", "provider_account"}, "", ""},
+		{"getLNPAttach", CategoryLNP, false, false, []string{"portid", "attachid"}, "", ""},
+		{"getLNPAttachList", CategoryLNP, false, false, []string{"portid"}, "", ""},
+		{"getLNPDetails", CategoryLNP, false, false, []string{"portid"}, "", ""},
+		{"getLNPList", CategoryLNP, false, false, nil, "", ""},
+		{"getLNPListStatus", CategoryLNP, false, false, nil, "", ""},
+		{"getLNPNotes", CategoryLNP, false, false, []string{"portid"}, "", ""},
+		{"getLNPStatus", CategoryLNP, false, false, []string{"portid"}, "", ""},
+
+		//Voicemail
+		{"createVoicemail", CategoryVoicemail, true, false, []string{"digits", "name", "password", "skip_password", "attach_message", "delete_message", "say_time", "timezone", "say_callerid", "play_instructions", "language"}, "", ""},
+		{"delMessages", CategoryVoicemail, true, false, []string{"mailbox"}, "", ""},
+		{"delVoicemail", CategoryVoicemail, true, false, []string{"mailbox"}, "", ""},
+		{"getPlayInstructions", CategoryVoicemail, false, false, nil, "", ""},
+		{"getTimezones", CategoryVoicemail, false, false, nil, "", ""},
+		{"getVoicemailFolders", CategoryVoicemail, false, false, nil, "", ""},
+		{"getVoicemailMessageFile", CategoryVoicemail, false, false, []string{"mailbox", "folder", "message_num"}, "", ""},
+		{"getVoicemailMessages", CategoryVoicemail, false, false, []string{"mailbox"}, "", ""},
+		{"getVoicemails", CategoryVoicemail, false, false, nil, "", ""},
+		{"markListenedVoicemailMessage", CategoryVoicemail, true, false, []string{"mailbox", "folder", "message_num", "listened"}, "", ""},
+		{"markUrgentVoicemailMessage", CategoryVoicemail, true, false, []string{"mailbox", "folder", "message_num", "urgent"}, "", ""},
+		{"moveFolderVoicemailMessage", CategoryVoicemail, true, false, []string{"mailbox", "folder", "message_num", "new_folder"}, "", ""},
+		{"sendVoicemailEmail", CategoryVoicemail, true, false, []string{"mailbox", "folder", "message_num", "email_address"}, "", ""},
+		{"setVoicemail", CategoryVoicemail, true, false, []string{"mailbox", "name", "password", "skip_password", "attach_message", "delete_message", "say_time", "timezone", "say_callerid", "play_instructions", "language"}, "", ""},
+
+		//Conference
+		{"delConference", CategoryConference, true, false, []string{"conference"}, "", ""},
+		{"delConferenceMember", CategoryConference, true, false, []string{"member"}, "", ""},
+		{"getConference", CategoryConference, false, false, nil, "", ""},
+		{"getConferenceMembers", CategoryConference, false, false, nil, "", ""},
+		{"getConferenceRecordings", CategoryConference, false, false, []string{"conference"}, "", ""},
+		{"getConferenceRecordingFile", CategoryConference, false, false, []string{"conference", "recording"}, "", ""},
+		{"setConference", CategoryConference, true, false, []string{"name"}, "", ""},
+		{"setConferenceMember", CategoryConference, true, false, []string{"name"}, "", ""},
+	} {
+		switch {
+		case m.Status != "":
+		case m.Func != "":
+			m.Status = StatusImplemented
+		default:
+			m.Status = StatusMissing
+		}
+		catalog[m.Name] = m
+	}
+}
+
+//Returns the catalog entry for a voip.ms method name.
+func LookupMethod(name string) (MethodInfo, bool) {
+	m, ok := catalog[name]
+	return m, ok
+}
+
+//Returns every voip.ms method, sorted by category then name. The slice is a copy and can be changed.
+func Methods() []MethodInfo {
+	methods := make([]MethodInfo, 0, len(catalog))
+	for _, m := range catalog {
+		m.Required = append([]string(nil), m.Required...)
+		methods = append(methods, m)
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].Category != methods[j].Category {
+			return methods[i].Category < methods[j].Category
+		}
+		return methods[i].Name < methods[j].Name
+	})
+
+	return methods
+}
+
+//True if method changes something. Methods missing from the catalog are judged by name, anything that isn't a get* or
+//search* is assumed to.
+func mutates(method string) bool {
+	if m, ok := catalog[method]; ok {
+		return m.Mutates
+	}
+	return !strings.HasPrefix(method, "get") && !strings.HasPrefix(method, "search")
+}
+
+func supportsTest(method string) bool {
+	return catalog[method].SupportsTest
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethods_FuncsExist(t *testing.T) {
+	types := map[string]reflect.Type{
+		"GeneralAPI":  reflect.TypeOf(&GeneralAPI{}),
+		"AccountsAPI": reflect.TypeOf(&AccountsAPI{}),
+		"CDRAPI":      reflect.TypeOf(&CDRAPI{}),
+		"ClientsAPI":  reflect.TypeOf(&ClientsAPI{}),
+		"DIDsAPI":     reflect.TypeOf(&DIDsAPI{}),
+	}
+
+	for _, m := range Methods() {
+		if m.Func == "" {
+			require.NotEqual(t, StatusImplemented, m.Status, m.Name)
+			continue
+		}
+
+		parts := strings.Split(m.Func, ".")
+		require.Len(t, parts, 2, m.Name)
+		typ, ok := types[parts[0]]
+		require.True(t, ok, m.Name)
+
+		_, ok = typ.MethodByName(parts[1])
+		require.True(t, ok, m.Func)
+		if m.Status == StatusStubbed {
+			continue
+		}
+		_, ok = typ.MethodByName(parts[1] + "Context")
+		require.True(t, ok, m.Func+"Context")
+		require.Equal(t, StatusImplemented, m.Status, m.Name)
+	}
+}
+
+func TestMethods_Consistent(t *testing.T) {
+	methods := Methods()
+
+	for i, m := range methods {
+		require.NotEqual(t, "", string(m.Category), m.Name)
+		if m.SupportsTest {
+			require.True(t, m.Mutates, m.Name)
+		}
+		if i > 0 && methods[i-1].Category == m.Category {
+			require.True(t, methods[i-1].Name < m.Name, m.Name)
+		}
+	}
+
+	methods[0].Required = append(methods[0].Required, "changed")
+	methods[0].Name = "changed"
+	require.NotEqual(t, "changed", Methods()[0].Name)
+}
+
+func TestLookupMethod(t *testing.T) {
+
+	//execute
+	order, okOrder := LookupMethod("orderDID")
+	fax, okFax := LookupMethod("sendFaxMessage")
+	e911, okE911 := LookupMethod("e911Validate")
+	_, okUnknown := LookupMethod("orderPizza")
+
+	//verify
+	require.True(t, okOrder)
+	require.Equal(t, CategoryDIDs, order.Category)
+	require.True(t, order.Mutates)
+	require.True(t, order.SupportsTest)
+	require.Contains(t, order.Required, "routing")
+	require.Equal(t, "DIDsAPI.OrderDID", order.Func)
+	require.Equal(t, StatusImplemented, order.Status)
+
+	require.True(t, okFax)
+	require.Equal(t, StatusMissing, fax.Status)
+	require.Equal(t, "", fax.Func)
+
+	require.True(t, okE911)
+	require.False(t, e911.Mutates)
+	require.Equal(t, StatusMissing, e911.Status)
+
+	require.False(t, okUnknown)
+	require.True(t, mutates("orderPizza"))
+	require.False(t, mutates("getPizza"))
+}
+
+func TestMethods_Status(t *testing.T) {
+	stubbed := map[string]string{
+		"setDIDRouting": "DIDsAPI.SetDIDRouting",
+		"setDIDInfo":    "DIDsAPI.SetDIDInfo",
+		"sendSMS":       "DIDsAPI.SendSMS",
+		"searchDIDsUSA": "DIDsAPI.SearchDIDsUSA",
+		"unconnectDID":  "DIDsAPI.UnconnectDID",
+	}
+	for name, fn := range stubbed {
+		m, ok := LookupMethod(name)
+		require.True(t, ok, name)
+		require.Equal(t, StatusStubbed, m.Status, name)
+		require.Equal(t, fn, m.Func, name)
+
+		//The stub is there and says so.
+		method := reflect.ValueOf(&DIDsAPI{}).MethodByName(strings.TrimPrefix(fn, "DIDsAPI."))
+		results := method.Call(make([]reflect.Value, method.Type().NumIn()))
+		err, _ := results[len(results)-1].Interface().(error)
+		require.EqualError(t, err, "NOT IMPLEMENTED YET!", name)
+	}
+
+	for _, name := range []string{"sendFaxMessage", "getFaxMessages", "getVoicemails", "createVoicemail", "getConference"} {
+		m, ok := LookupMethod(name)
+		require.True(t, ok, name)
+		require.Equal(t, StatusMissing, m.Status, name)
+	}
+
+	//Fax and voicemail have no API yet.
+	for _, m := range Methods() {
+		if m.Category == CategoryFax || m.Category == CategoryVoicemail {
+			require.Equal(t, StatusMissing, m.Status, m.Name)
+		}
+	}
+}
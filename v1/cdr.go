@@ -1,12 +1,12 @@
 package v1
 
 import (
+	"context"
 	"net/url"
 	"time"
 	"errors"
-	"fmt"
+	"strconv"
 	"encoding/json"
-	"strings"
 )
 
 type CDRAPI struct {
@@ -47,40 +47,36 @@ type GetCDRResp struct {
 }
 
 type CDR struct {
-	Date        time.Time `json:"date"`
+	Date        time.Time `json:"date"` //In the timezone the CDRs were requested in. UTC when decoded from voip.ms on its own.
 	CallerId    string `json:"callerid"`
 	Destination string `json:"destination"`
 	Description string `json:"description"`
 	Account     string `json:"account"`
 	Disposition string `json:"disposition"`
 	Duration    time.Duration `json:"duration"`
-	Seconds     int `json:"seconds,string"`
-	Rate        float64 `json:"rate,string,omitempty"`
-	Total       float64 `json:"total,string"`
+	Seconds     FlexInt `json:"seconds"`
+	Rate        Money `json:"rate,omitempty"`
+	Total       Money `json:"total"`
 	UniqueId    string `json:"uniqueid"`
 }
 
-/*
-func (c *CDR) MarshalJSON() ([]byte, error) {
+//Encodes the date as RFC 3339 so its UTC offset survives and the duration as "HH:MM:SS". UnmarshalJSON decodes it
+//back to the same instant.
+func (c CDR) MarshalJSON() ([]byte, error) {
 	type Alias CDR
-	
-	str := c.Duration.String()
-	str = strings.Replace(str, "h", ":", 1)
-	str = strings.Replace(str, "m", ":", 1)
-	str = strings.Replace(str, "s", ":", 1)
-	
+
 	return json.Marshal(&struct {
-		Date string `json:"date"`
+		Date     string `json:"date"`
 		Duration string `json:"duration"`
 		*Alias
 	}{
-		Date: c.Date.Format("2006-01-02 15:04:05"),
-		Duration: str,
-		Alias:    (*Alias)(c),
+		Date:     c.Date.Format(time.RFC3339Nano),
+		Duration: FormatClock(c.Duration),
+		Alias:    (*Alias)(&c),
 	})
 }
-*/
 
+//Reads the date as voip.ms sends it, "2006-01-02 15:04:05", or with an offset as written by MarshalJSON.
 func (c *CDR) UnmarshalJSON(data []byte) error {
 
 	type Alias CDR
@@ -98,29 +94,18 @@ func (c *CDR) UnmarshalJSON(data []byte) error {
 	//date
 	d, err := time.Parse("2006-01-02 15:04:05", aux.Date)
 	if err != nil {
-		return err
+		if d, err = time.Parse(time.RFC3339Nano, aux.Date); err != nil {
+			return err
+		}
 	}
 	c.Date = d
 
 	//duration
-	str := strings.Split(aux.Duration, ":")
-	fmt.Println(str)
-	hour, err := time.ParseDuration(str[0] + "h")
-	if err != nil {
-		return err
-	}
-
-	min, err := time.ParseDuration(str[1] + "m")
-	if err != nil {
-		return err
-	}
-
-	sec, err := time.ParseDuration(str[2] + "s")
+	duration, err := ParseClock(aux.Duration)
 	if err != nil {
 		return err
 	}
-
-	c.Duration = hour + min + sec
+	c.Duration = duration
 
 	return nil
 }
@@ -133,10 +118,10 @@ type GetRatesResp struct {
 type Rate struct {
 	Destination     string `json:"destination"`
 	Prefix          string `json:"prefix"`
-	ClientIncrement int `json:"client_increment"`
-	ClientRate      float64 `json:"client_rate"`
-	RealIncrement   int `json:"real_increment"`
-	RealRate        float64 `json:"real_rate"`
+	ClientIncrement FlexInt `json:"client_increment"`
+	ClientRate      Money `json:"client_rate"`
+	RealIncrement   FlexInt `json:"real_increment"`
+	RealRate        Money `json:"real_rate"`
 }
 
 type GetTerminationRatesRep struct {
@@ -148,18 +133,21 @@ type GetTerminationRatesRep struct {
 type TerminationRate struct {
 	Destination string `json:"destination"`
 	Prefix      string `json:"prefix"`
-	Increment   int `json:"increment"`
-	Rate        float64 `json:"rate"`
+	Increment   FlexInt `json:"increment"`
+	Rate        Money `json:"rate"`
 }
 
 func (c *CDRAPI) GetCallAccounts(clientId string) ([]CallAccount, error) {
-	values := url.Values{}
-	if clientId != "" {
-		values.Add("client", clientId)
-	}
+	return c.GetCallAccountsContext(context.Background(), clientId)
+}
+
+func (c *CDRAPI) GetCallAccountsContext(ctx context.Context, clientId string) ([]CallAccount, error) {
+	rq := struct {
+		Client string `url:"client,omitempty"`
+	}{clientId}
 
 	rs := &GetCallAccountsResp{}
-	if err := c.client.Get("getCallAccounts", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getCallAccounts", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -167,10 +155,12 @@ func (c *CDRAPI) GetCallAccounts(clientId string) ([]CallAccount, error) {
 }
 
 func (c *CDRAPI) GetCallBilling() ([]CallBilling, error) {
-	values := url.Values{}
+	return c.GetCallBillingContext(context.Background())
+}
 
+func (c *CDRAPI) GetCallBillingContext(ctx context.Context) ([]CallBilling, error) {
 	rs := &GetCallBillingResp{}
-	if err := c.client.Get("getCallBilling", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getCallBilling", nil, rs); err != nil {
 		return nil, err
 	}
 
@@ -178,40 +168,54 @@ func (c *CDRAPI) GetCallBilling() ([]CallBilling, error) {
 }
 
 func (c *CDRAPI) GetCallTypes(clientId string) ([]CallType, error) {
-	values := url.Values{}
-	if clientId != "" {
-		values.Add("client", clientId)
-	}
+	return c.GetCallTypesContext(context.Background(), clientId)
+}
+
+func (c *CDRAPI) GetCallTypesContext(ctx context.Context, clientId string) ([]CallType, error) {
+	rq := struct {
+		Client string `url:"client,omitempty"`
+	}{clientId}
 
 	rs := &GetCallTypeResp{}
-	if err := c.client.Get("getCallTypes", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getCallTypes", rq, rs); err != nil {
 		return nil, err
 	}
 
 	return rs.CallTypes, nil
 }
 
+//voip.ms applies a single UTC offset to the whole range, the one timezone has on dateFrom. Dates are still returned at
+//the right instant, but after a DST change the range's days start an hour off local midnight.
 func (c *CDRAPI) GetCDR(dateFrom, dateTo time.Time, callStatus CallStatus, timezone *time.Location, callType, callBilling, account string) ([]CDR, error) {
-	values, err := buildCDR(dateFrom, dateTo, callStatus, timezone, callType, callBilling, account)
+	return c.GetCDRContext(context.Background(), dateFrom, dateTo, callStatus, timezone, callType, callBilling, account)
+}
+
+func (c *CDRAPI) GetCDRContext(ctx context.Context, dateFrom, dateTo time.Time, callStatus CallStatus, timezone *time.Location, callType, callBilling, account string) ([]CDR, error) {
+	rq, err := buildCDR(dateFrom, dateTo, callStatus, timezone, callType, callBilling, account)
 	if err != nil {
 		return nil, err
 	}
 
 	rs := &GetCDRResp{}
-	if err := c.client.Get("getCDR", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getCDR", rq, rs); err != nil {
 		return nil, err
 	}
 
-	return rs.CDRs, nil
+	return zoneCDRs(rs.CDRs, dateFrom, timezone), nil
 }
 
 func (c *CDRAPI) GetRates(packag3, query string) ([]Rate, error) {
-	values := url.Values{}
-	values.Add("package", packag3)
-	values.Add("query", query)
+	return c.GetRatesContext(context.Background(), packag3, query)
+}
+
+func (c *CDRAPI) GetRatesContext(ctx context.Context, packag3, query string) ([]Rate, error) {
+	rq := struct {
+		Package string `url:"package"`
+		Query   string `url:"query"`
+	}{packag3, query}
 
 	rs := &GetRatesResp{}
-	if err := c.client.Get("getRates", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getRates", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -219,12 +223,17 @@ func (c *CDRAPI) GetRates(packag3, query string) ([]Rate, error) {
 }
 
 func (c *CDRAPI) GetTerminationRates(route, query string) ([]TerminationRate, error) {
-	values := url.Values{}
-	values.Add("route", route)
-	values.Add("query", query)
+	return c.GetTerminationRatesContext(context.Background(), route, query)
+}
+
+func (c *CDRAPI) GetTerminationRatesContext(ctx context.Context, route, query string) ([]TerminationRate, error) {
+	rq := struct {
+		Route string `url:"route"`
+		Query string `url:"query"`
+	}{route, query}
 
 	rs := &GetTerminationRatesRep{}
-	if err := c.client.Get("getTerminationRates", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getTerminationRates", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -232,59 +241,82 @@ func (c *CDRAPI) GetTerminationRates(route, query string) ([]TerminationRate, er
 }
 
 func (c *CDRAPI) GetResellerCDR(dateFrom, dateTo time.Time, client string, callStatus CallStatus, timezone *time.Location, callType, callBilling, account string) ([]CDR, error) {
-	values, err := buildCDR(dateFrom, dateTo, callStatus, timezone, callType, callBilling, account)
+	return c.GetResellerCDRContext(context.Background(), dateFrom, dateTo, client, callStatus, timezone, callType, callBilling, account)
+}
+
+func (c *CDRAPI) GetResellerCDRContext(ctx context.Context, dateFrom, dateTo time.Time, client string, callStatus CallStatus, timezone *time.Location, callType, callBilling, account string) ([]CDR, error) {
+	rq, err := buildCDR(dateFrom, dateTo, callStatus, timezone, callType, callBilling, account)
 	if err != nil {
 		return nil, err
 	}
 
-	values.Add("client", client)
-	
+	rq.Add("client", client)
+
 	rs := &GetCDRResp{}
-	if err := c.client.Get("getResellerCDR", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getResellerCDR", rq, rs); err != nil {
 		return nil, err
 	}
 
-	return rs.CDRs, nil
+	return zoneCDRs(rs.CDRs, dateFrom, timezone), nil
+}
+
+//Parameters shared by getCDR and getResellerCDR.
+type cdrReq struct {
+	DateFrom    string `url:"date_from"`
+	DateTo      string `url:"date_to"`
+	Timezone    string `url:"timezone"`
+	Answered    bool   `url:"answered,int,omitempty"`
+	NoAnswer    bool   `url:"noanswer,int,omitempty"`
+	Busy        bool   `url:"busy,int,omitempty"`
+	Failed      bool   `url:"failed,int,omitempty"`
+	CallType    string `url:"calltype"`
+	CallBilling string `url:"callbilling"`
+	Account     string `url:"account"`
 }
 
 func buildCDR(dateFrom, dateTo time.Time, callStatus CallStatus, timezone *time.Location, callType, callBilling, account string) (url.Values, error) {
-	values := url.Values{}
 	if dateFrom.IsZero() {
 		return nil, errors.New("dateFrom is required!")
 	}
-	values.Add("date_from", dateFrom.Format("2006-01-02"))
 
 	if dateTo.IsZero() {
 		return nil, errors.New("dateTo is required!")
 	}
-	values.Add("date_to", dateTo.Format("2006-01-02"))
 
-	if timezone.String() == "" {
+	if timezone == nil || timezone.String() == "" {
 		return nil, errors.New("timezone is required!")
 	}
-	_, offset := time.Now().In(timezone).Zone()
-	d := time.Duration(offset) * time.Second
-	values.Add("timezone", fmt.Sprintf("%.2g", d.Hours()))
-
-	if callStatus.Answered {
-		values.Add("answered", "1")
-	}
-
-	if callStatus.NoAnswer {
-		values.Add("noanswer", "1")
-	}
+	hours := float64(cdrOffset(dateFrom, timezone)) / 3600
+
+	return EncodeValues(&cdrReq{
+		DateFrom:    dateFrom.Format("2006-01-02"),
+		DateTo:      dateTo.Format("2006-01-02"),
+		Timezone:    strconv.FormatFloat(hours, 'f', -1, 64), //i.e. -7, -3.5 or 5.75
+		Answered:    callStatus.Answered,
+		NoAnswer:    callStatus.NoAnswer,
+		Busy:        callStatus.Busy,
+		Failed:      callStatus.Failed,
+		CallType:    callType,
+		CallBilling: callBilling,
+		Account:     account,
+	})
+}
 
-	if callStatus.Busy {
-		values.Add("busy", "1")
-	}
+//Returns timezone's UTC offset in seconds on date. Noon is used so the day a DST change happens on gets the offset
+//most of it is in.
+func cdrOffset(date time.Time, timezone *time.Location) int {
+	y, m, d := date.Date()
+	_, offset := time.Date(y, m, d, 12, 0, 0, 0, timezone).Zone()
+	return offset
+}
 
-	if callStatus.Failed {
-		values.Add("failed", "1")
+//CDR dates come back as wall clock times at the offset the range was requested with. Moves them to the instant they
+//represent, in timezone.
+func zoneCDRs(cdrs []CDR, dateFrom time.Time, timezone *time.Location) []CDR {
+	zone := time.FixedZone("", cdrOffset(dateFrom, timezone))
+	for i := range cdrs {
+		t := cdrs[i].Date
+		cdrs[i].Date = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), zone).In(timezone)
 	}
-
-	values.Add("calltype", callType)
-	values.Add("callbilling", callBilling)
-	values.Add("account", account)
-
-	return values, nil
-}
\ No newline at end of file
+	return cdrs
+}
@@ -20,12 +20,12 @@ func TestCDRAPI_GetCallAccounts(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallAccounts"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallAccounts"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	accounts, err := api.GetCallAccounts("")
@@ -46,12 +46,12 @@ func TestCDRAPI_GetCallAccounts_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallAccounts"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallAccounts"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	accounts, err := api.GetCallAccounts("")
@@ -71,13 +71,13 @@ func TestCDRAPI_GetCallAccounts_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallAccounts"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"100000_VoIP"}, r.URL.Query()["client"])
+		require.Equal(t, []string{"getCallAccounts"}, formValues(r)["method"])
+		require.Equal(t, []string{"100000_VoIP"}, formValues(r)["client"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	accounts, err := api.GetCallAccounts("100000_VoIP")
@@ -98,12 +98,12 @@ func TestCDRAPI_GetCallBilling(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallBilling"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallBilling"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	callBillings, err := api.GetCallBilling()
@@ -124,12 +124,12 @@ func TestCDRAPI_GetCallBilling_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallBilling"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallBilling"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	callBillings, err := api.GetCallBilling()
@@ -149,12 +149,12 @@ func TestCDRAPI_GetCallTypes(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	callTypes, err := api.GetCallTypes("")
@@ -175,12 +175,12 @@ func TestCDRAPI_GetCallTypes_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallTypes"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCallTypes"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	callTypes, err := api.GetCallTypes("")
@@ -200,13 +200,13 @@ func TestCDRAPI_GetCallTypes_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCallTypes"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"1234"}, r.URL.Query()["client"])
+		require.Equal(t, []string{"getCallTypes"}, formValues(r)["method"])
+		require.Equal(t, []string{"1234"}, formValues(r)["client"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	callTypes, err := api.GetCallTypes("1234")
@@ -236,22 +236,22 @@ func TestCDRAPI_GetCDR(t *testing.T) {
 	}]}`
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCDR"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2016-10-26"}, r.URL.Query()["date_from"])
-		require.Equal(t, []string{"2016-11-07"}, r.URL.Query()["date_to"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["answered"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["noanswer"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["busy"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["failed"])
-		require.Equal(t, []string{"-7"}, r.URL.Query()["timezone"])
-		require.Equal(t, []string{"all"}, r.URL.Query()["calltype"])
-		require.Equal(t, []string{"cb"}, r.URL.Query()["callbilling"])
-		require.Equal(t, []string{"a"}, r.URL.Query()["account"])
+		require.Equal(t, []string{"getCDR"}, formValues(r)["method"])
+		require.Equal(t, []string{"2016-10-26"}, formValues(r)["date_from"])
+		require.Equal(t, []string{"2016-11-07"}, formValues(r)["date_to"])
+		require.Equal(t, []string{"1"}, formValues(r)["answered"])
+		require.Equal(t, []string{"1"}, formValues(r)["noanswer"])
+		require.Equal(t, []string{"1"}, formValues(r)["busy"])
+		require.Equal(t, []string{"1"}, formValues(r)["failed"])
+		require.Equal(t, []string{"-6"}, formValues(r)["timezone"])
+		require.Equal(t, []string{"all"}, formValues(r)["calltype"])
+		require.Equal(t, []string{"cb"}, formValues(r)["callbilling"])
+		require.Equal(t, []string{"a"}, formValues(r)["account"])
 		fmt.Fprintln(w, result)
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	cs := CallStatus{true, true, true, true}
 	mst, _ := time.LoadLocation("America/Edmonton")
@@ -270,9 +270,11 @@ func TestCDRAPI_GetCDR(t *testing.T) {
 	require.Equal(t, "123456", cdrs[0].Account)
 	require.Equal(t, "ANSWERED", cdrs[0].Disposition)
 	require.Equal(t, time.Second * 5, cdrs[0].Duration)
-	require.Equal(t, 0.009, cdrs[0].Rate)
-	require.Equal(t, 0.0009, cdrs[0].Total)
+	require.Equal(t, MustParseMoney("0.009"), cdrs[0].Rate)
+	require.Equal(t, MustParseMoney("0.0009"), cdrs[0].Total)
 	require.Equal(t, "982384595", cdrs[0].UniqueId)
+	require.Equal(t, mst, cdrs[0].Date.Location())
+	require.Equal(t, time.Date(2016, 11, 7, 16, 17, 34, 0, time.UTC), cdrs[0].Date.UTC())
 }
 
 func TestCDRAPI_GetCDR_Error(t *testing.T) {
@@ -286,7 +288,7 @@ func TestCDRAPI_GetCDR_Error(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	cs := CallStatus{true, false, false, false}
 	mst, _ := time.LoadLocation("America/Edmonton")
@@ -311,21 +313,21 @@ func TestCDRAPI_GetRates(t *testing.T) {
 				Destination: "all",
 				Prefix: "All Calls",
 				ClientIncrement: 60,
-				ClientRate: 0.015,
+				ClientRate: MustParseMoney("0.015"),
 				RealIncrement: 6,
-				RealRate: 0.0052,
+				RealRate: MustParseMoney("0.0052"),
 			},
 		},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRates"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRates"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	rates, err := api.GetRates("1234", "Canada")
@@ -346,12 +348,12 @@ func TestCDRAPI_GetRates_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getRates"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getRates"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	rates, err := api.GetRates("1234", "Canada")
@@ -366,25 +368,25 @@ func TestCDRAPI_GetTerminationRates(t *testing.T) {
 	//setup
 	rq := GetTerminationRatesRep{
 		BaseResp{"success"},
-		NumberValueDescription{"2", "Premium"},
+		NumberValueDescription{2, "Premium"},
 		[]TerminationRate{
 			{
 				Destination: "Canada - 204 Manitoba",
 				Prefix: "1204",
 				Increment: 6,
-				Rate: 0.009,
+				Rate: MustParseMoney("0.009"),
 			},
 		},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getTerminationRates"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getTerminationRates"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	rates, err := api.GetTerminationRates("2", "Canada")
@@ -406,12 +408,12 @@ func TestCDRAPI_GetTerminationRates_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getTerminationRates"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getTerminationRates"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	//execute
 	rates, err := api.GetTerminationRates("2", "Canada")
@@ -439,23 +441,23 @@ func TestCDRAPI_GetResellerCDR(t *testing.T) {
 	}]}`
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getResellerCDR"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"2016-10-26"}, r.URL.Query()["date_from"])
-		require.Equal(t, []string{"2016-11-07"}, r.URL.Query()["date_to"])
-		require.Equal(t, []string{"client"}, r.URL.Query()["client"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["answered"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["noanswer"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["busy"])
-		require.Equal(t, []string{"1"}, r.URL.Query()["failed"])
-		require.Equal(t, []string{"-7"}, r.URL.Query()["timezone"])
-		require.Equal(t, []string{"all"}, r.URL.Query()["calltype"])
-		require.Equal(t, []string{"cb"}, r.URL.Query()["callbilling"])
-		require.Equal(t, []string{"a"}, r.URL.Query()["account"])
+		require.Equal(t, []string{"getResellerCDR"}, formValues(r)["method"])
+		require.Equal(t, []string{"2016-10-26"}, formValues(r)["date_from"])
+		require.Equal(t, []string{"2016-11-07"}, formValues(r)["date_to"])
+		require.Equal(t, []string{"client"}, formValues(r)["client"])
+		require.Equal(t, []string{"1"}, formValues(r)["answered"])
+		require.Equal(t, []string{"1"}, formValues(r)["noanswer"])
+		require.Equal(t, []string{"1"}, formValues(r)["busy"])
+		require.Equal(t, []string{"1"}, formValues(r)["failed"])
+		require.Equal(t, []string{"-6"}, formValues(r)["timezone"])
+		require.Equal(t, []string{"all"}, formValues(r)["calltype"])
+		require.Equal(t, []string{"cb"}, formValues(r)["callbilling"])
+		require.Equal(t, []string{"a"}, formValues(r)["account"])
 		fmt.Fprintln(w, result)
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	cs := CallStatus{true, true, true, true}
 	mst, _ := time.LoadLocation("America/Edmonton")
@@ -474,8 +476,8 @@ func TestCDRAPI_GetResellerCDR(t *testing.T) {
 	require.Equal(t, "123456", cdrs[0].Account)
 	require.Equal(t, "ANSWERED", cdrs[0].Disposition)
 	require.Equal(t, time.Second * 5, cdrs[0].Duration)
-	require.Equal(t, 0.0, cdrs[0].Rate)
-	require.Equal(t, 0.0009, cdrs[0].Total)
+	require.Equal(t, Money{}, cdrs[0].Rate)
+	require.Equal(t, MustParseMoney("0.0009"), cdrs[0].Total)
 	require.Equal(t, "982384595", cdrs[0].UniqueId)
 }
 
@@ -490,7 +492,7 @@ func TestCDRAPI_GetResellerCDR_Error(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewCDRAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewCDRAPI()
 
 	cs := CallStatus{true, false, false, false}
 	mst, _ := time.LoadLocation("America/Edmonton")
@@ -554,4 +556,86 @@ func TestBuildCDR_NoTimezone(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "timezone is required!")
 	require.Len(t, cdrs, 0)
-}
\ No newline at end of file
+}
+
+func TestBuildCDR_Timezone(t *testing.T) {
+	for _, tc := range []struct {
+		zone     string
+		date     string
+		expected string
+	}{
+		{"America/Edmonton", "2020-01-15", "-7"},
+		{"America/Edmonton", "2020-07-01", "-6"},
+		{"America/Edmonton", "2020-03-08", "-6"}, //DST starts at 2am.
+		{"America/Edmonton", "2020-11-01", "-7"}, //And ends at 2am.
+		{"America/St_Johns", "2020-01-15", "-3.5"},
+		{"America/St_Johns", "2020-07-01", "-2.5"},
+		{"Asia/Kolkata", "2020-01-15", "5.5"},
+		{"Asia/Kathmandu", "2020-01-15", "5.75"},
+		{"Pacific/Kiritimati", "2020-01-15", "14"},
+		{"UTC", "2020-01-15", "0"},
+	} {
+		loc, err := time.LoadLocation(tc.zone)
+		require.NoError(t, err)
+		date, _ := time.Parse("2006-01-02", tc.date)
+
+		values, err := buildCDR(date, date, CallStatus{}, loc, "", "", "")
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, values.Get("timezone"), tc.zone+" "+tc.date)
+	}
+
+	_, err := buildCDR(time.Now(), time.Now(), CallStatus{}, nil, "", "", "")
+	require.EqualError(t, err, "timezone is required!")
+}
+
+func TestZoneCDRs(t *testing.T) {
+
+	//setup
+	mst, _ := time.LoadLocation("America/Edmonton")
+	nst, _ := time.LoadLocation("America/St_Johns")
+	cdrs := []CDR{{Date: time.Date(2020, 3, 8, 1, 30, 0, 0, time.UTC)}, {Date: time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC)}}
+	nfld := []CDR{{Date: time.Date(2020, 1, 15, 9, 0, 0, 0, time.UTC)}}
+
+	//execute
+	zoneCDRs(cdrs, time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC), mst)
+	zoneCDRs(nfld, time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), nst)
+
+	//verify
+	//The window was requested at -6. 01:30 at -6 is before the change, so it's 00:30 MST.
+	require.Equal(t, time.Date(2020, 3, 8, 7, 30, 0, 0, time.UTC), cdrs[0].Date.UTC())
+	require.Equal(t, "2020-03-08 00:30:00 MST", cdrs[0].Date.Format("2006-01-02 15:04:05 MST"))
+	require.Equal(t, "2020-03-08 12:00:00 MDT", cdrs[1].Date.Format("2006-01-02 15:04:05 MST"))
+	require.Equal(t, time.Date(2020, 1, 15, 12, 30, 0, 0, time.UTC), nfld[0].Date.UTC())
+	require.Equal(t, nst, nfld[0].Date.Location())
+}
+
+func TestCDR_MarshalJSON(t *testing.T) {
+
+	//setup
+	mst, _ := time.LoadLocation("America/Edmonton")
+	cdr := CDR{
+		Date:     time.Date(2016, 11, 7, 10, 17, 34, 0, mst),
+		Duration: 26*time.Hour + 5*time.Second,
+		Seconds:  93605,
+		Rate:     MustParseMoney("0.009"),
+		Total:    MustParseMoney("0.0009"),
+		UniqueId: "982384595",
+	}
+
+	//execute
+	b, err := json.Marshal(cdr)
+	decoded := CDR{}
+	derr := json.Unmarshal(b, &decoded)
+
+	//verify
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"date":"2016-11-07T10:17:34-07:00"`)
+	require.Contains(t, string(b), `"duration":"26:00:05"`)
+	require.Contains(t, string(b), `"total":"0.0009"`)
+	require.NoError(t, derr)
+	require.True(t, cdr.Date.Equal(decoded.Date), decoded.Date.String())
+	require.Equal(t, "2016-11-07T10:17:34-07:00", decoded.Date.Format(time.RFC3339))
+	again, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	require.Equal(t, string(b), string(again))
+}
@@ -0,0 +1,270 @@
+//Package cdrexport streams CDRs to and from CSV, JSON Lines and a simple columnar format for finance and BI tools.
+//Writers hold a single record, or a single row group for the columnar format, so exports of any size run in constant
+//memory:
+//
+//	it := cdrAPI.Query(ctx, q)
+//	w := cdrexport.NewCSVWriter(f, cdrexport.Options{Location: loc})
+//	n, err := cdrexport.Copy(w, it)
+//
+//Readers take the same Options and return the records with Read until io.EOF, or all at once with ReadAll.
+package cdrexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//A CDR field. The names match voip.ms's JSON field names.
+type Column string
+
+const (
+	ColumnDate        Column = "date"
+	ColumnCallerID    Column = "callerid"
+	ColumnDestination Column = "destination"
+	ColumnDescription Column = "description"
+	ColumnAccount     Column = "account"
+	ColumnDisposition Column = "disposition"
+	ColumnDuration    Column = "duration"
+	ColumnSeconds     Column = "seconds"
+	ColumnRate        Column = "rate"
+	ColumnTotal       Column = "total"
+	ColumnUniqueID    Column = "uniqueid"
+)
+
+//Every column, in voip.ms's order. Used when Options.Columns is empty.
+var DefaultColumns = []Column{
+	ColumnDate, ColumnCallerID, ColumnDestination, ColumnDescription, ColumnAccount, ColumnDisposition, ColumnDuration,
+	ColumnSeconds, ColumnRate, ColumnTotal, ColumnUniqueID,
+}
+
+//How CDR.Duration is written.
+type DurationFormat int
+
+const (
+	DurationClock   DurationFormat = iota //HH:MM:SS, as voip.ms sends it.
+	DurationSeconds                       //Whole seconds, i.e. 125.
+	DurationGo                            //time.Duration's String, i.e. 2m5s.
+)
+
+type Options struct {
+	Columns    []Column       //Columns written, in order. DefaultColumns when empty. Readers use the file's own.
+	Location   *time.Location //Dates are written in, and read without an offset as, this timezone. Defaults to the CDR's own, UTC when reading.
+	DateFormat string         //time layout for dates. Defaults to time.RFC3339.
+	Duration   DurationFormat
+}
+
+func (o Options) columns() []Column {
+	if len(o.Columns) == 0 {
+		return DefaultColumns
+	}
+	return o.Columns
+}
+
+func (o Options) dateFormat() string {
+	if o.DateFormat == "" {
+		return time.RFC3339
+	}
+	return o.DateFormat
+}
+
+func (o Options) validate() error {
+	seen := map[Column]bool{}
+	for _, col := range o.columns() {
+		if !isColumn(col) {
+			return fmt.Errorf("cdrexport: unknown column %q", col)
+		}
+		if seen[col] {
+			return fmt.Errorf("cdrexport: column %q given twice", col)
+		}
+		seen[col] = true
+	}
+	return nil
+}
+
+func isColumn(col Column) bool {
+	for _, c := range DefaultColumns {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
+
+//Writes CDRs one at a time. Close flushes anything buffered, it doesn't close the underlying io.Writer.
+type Writer interface {
+	Write(cdr v1.CDR) error
+	Close() error
+}
+
+//Reads CDRs one at a time, returning io.EOF after the last.
+type Reader interface {
+	Read() (v1.CDR, error)
+}
+
+//Writes every CDR it returns to w and closes w, even when it fails part way so what was written is flushed. Returns
+//the number of CDRs written and the first error.
+func Copy(w Writer, it *v1.CDRIterator) (int, error) {
+	n := 0
+	var err error
+	for it.Next() {
+		if err = w.Write(it.CDR()); err != nil {
+			it.Close()
+			break
+		}
+		n++
+	}
+
+	if err == nil {
+		err = it.Err()
+	}
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+//Reads every remaining CDR from r.
+func ReadAll(r Reader) ([]v1.CDR, error) {
+	var cdrs []v1.CDR
+	for {
+		cdr, err := r.Read()
+		if err == io.EOF {
+			return cdrs, nil
+		}
+		if err != nil {
+			return cdrs, err
+		}
+		cdrs = append(cdrs, cdr)
+	}
+}
+
+//Returns col of cdr as text.
+func (o Options) format(cdr v1.CDR, col Column) string {
+	switch col {
+	case ColumnDate:
+		date := cdr.Date
+		if o.Location != nil {
+			date = date.In(o.Location)
+		}
+		return date.Format(o.dateFormat())
+	case ColumnCallerID:
+		return cdr.CallerId
+	case ColumnDestination:
+		return cdr.Destination
+	case ColumnDescription:
+		return cdr.Description
+	case ColumnAccount:
+		return cdr.Account
+	case ColumnDisposition:
+		return cdr.Disposition
+	case ColumnDuration:
+		switch o.Duration {
+		case DurationSeconds:
+			return strconv.FormatInt(int64(cdr.Duration/time.Second), 10)
+		case DurationGo:
+			return cdr.Duration.String()
+		}
+		return v1.FormatClock(cdr.Duration)
+	case ColumnSeconds:
+		return strconv.Itoa(cdr.Seconds.Int())
+	case ColumnRate:
+		return cdr.Rate.String()
+	case ColumnTotal:
+		return cdr.Total.String()
+	case ColumnUniqueID:
+		return cdr.UniqueId
+	}
+	return ""
+}
+
+//True for columns written as JSON numbers rather than strings.
+func (o Options) numeric(col Column) bool {
+	return col == ColumnSeconds || (col == ColumnDuration && o.Duration == DurationSeconds)
+}
+
+//Returns col of cdr as a JSON value.
+func (o Options) formatJSON(cdr v1.CDR, col Column) json.RawMessage {
+	s := o.format(cdr, col)
+	if o.numeric(col) {
+		return json.RawMessage(s)
+	}
+
+	//Without HTML escaping so caller ids like "Jane" <15554443333> stay readable.
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+//Sets col of cdr from text written by format.
+func (o Options) parse(cdr *v1.CDR, col Column, s string) error {
+	var err error
+	switch col {
+	case ColumnDate:
+		loc := o.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		cdr.Date, err = time.ParseInLocation(o.dateFormat(), s, loc)
+	case ColumnCallerID:
+		cdr.CallerId = s
+	case ColumnDestination:
+		cdr.Destination = s
+	case ColumnDescription:
+		cdr.Description = s
+	case ColumnAccount:
+		cdr.Account = s
+	case ColumnDisposition:
+		cdr.Disposition = s
+	case ColumnDuration:
+		cdr.Duration, err = o.parseDuration(s)
+	case ColumnSeconds:
+		var n int
+		n, err = strconv.Atoi(s)
+		cdr.Seconds = v1.FlexInt(n)
+	case ColumnRate:
+		cdr.Rate, err = v1.ParseMoney(s)
+	case ColumnTotal:
+		cdr.Total, err = v1.ParseMoney(s)
+	case ColumnUniqueID:
+		cdr.UniqueId = s
+	default:
+		return fmt.Errorf("cdrexport: unknown column %q", col)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cdrexport: %s: %w", col, err)
+	}
+	return nil
+}
+
+func (o Options) parseDuration(s string) (time.Duration, error) {
+	switch o.Duration {
+	case DurationSeconds:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return time.Duration(n) * time.Second, err
+	case DurationGo:
+		return time.ParseDuration(s)
+	}
+
+	return v1.ParseClock(s)
+}
+
+//Sets col of cdr from a JSON value written by formatJSON.
+func (o Options) parseJSON(cdr *v1.CDR, col Column, raw json.RawMessage) error {
+	s := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return fmt.Errorf("cdrexport: %s: %w", col, err)
+		}
+	}
+	return o.parse(cdr, col, s)
+}
@@ -0,0 +1,216 @@
+package cdrexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func testCDRs(t *testing.T, n int) []v1.CDR {
+	mst, err := time.LoadLocation("America/Edmonton")
+	require.NoError(t, err)
+
+	var cdrs []v1.CDR
+	for i := 0; i < n; i++ {
+		cdrs = append(cdrs, v1.CDR{
+			Date:        time.Date(2020, 3, 8, 10, i, 5, 0, mst),
+			CallerId:    `"Jane, Doe" <15554443333>`,
+			Destination: "15554441111",
+			Description: "Inbound DID",
+			Account:     "100000_office",
+			Disposition: "ANSWERED",
+			Duration:    time.Duration(i*60+5) * time.Second,
+			Seconds:     v1.FlexInt(i*60 + 5),
+			Rate:        v1.MustParseMoney("0.0090"),
+			Total:       v1.MustParseMoney("0.0009").Mul(int64(i + 1)),
+			UniqueId:    fmt.Sprintf("98238459%d", i),
+		})
+	}
+	return cdrs
+}
+
+func write(t *testing.T, w Writer, cdrs []v1.CDR) {
+	for _, cdr := range cdrs {
+		require.NoError(t, w.Write(cdr))
+	}
+	require.NoError(t, w.Close())
+}
+
+func requireSameCDRs(t *testing.T, expected, actual []v1.CDR) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, expected[i].Date.Equal(actual[i].Date), actual[i].Date.String())
+		actual[i].Date = expected[i].Date
+		require.Equal(t, expected[i], actual[i])
+	}
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+
+	//setup
+	cdrs := testCDRs(t, 3)
+	buf := &bytes.Buffer{}
+
+	//execute
+	write(t, NewCSVWriter(buf, Options{}), cdrs)
+	read, err := ReadAll(NewCSVReader(bytes.NewReader(buf.Bytes()), Options{}))
+
+	//verify
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), "date,callerid,destination,description,account,disposition,duration,seconds,rate,total,uniqueid\n"))
+	require.Contains(t, buf.String(), `2020-03-08T10:00:05-06:00,"""Jane, Doe"" <15554443333>",15554441111,Inbound DID,100000_office,ANSWERED,00:00:05,5,0.0090,0.0009,982384590`)
+	requireSameCDRs(t, cdrs, read)
+}
+
+func TestCSV_Options(t *testing.T) {
+
+	//setup
+	cdrs := testCDRs(t, 2)
+	buf := &bytes.Buffer{}
+	opts := Options{
+		Columns:    []Column{ColumnUniqueID, ColumnDate, ColumnDuration, ColumnTotal},
+		Location:   time.UTC,
+		DateFormat: "2006-01-02 15:04:05",
+		Duration:   DurationSeconds,
+	}
+
+	//execute
+	write(t, NewCSVWriter(buf, opts), cdrs)
+	read, err := ReadAll(NewCSVReader(bytes.NewReader(buf.Bytes()), opts))
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "uniqueid,date,duration,total\n982384590,2020-03-08 16:00:05,5,0.0009\n982384591,2020-03-08 16:01:05,65,0.0018\n", buf.String())
+	require.Len(t, read, 2)
+	require.True(t, cdrs[1].Date.Equal(read[1].Date))
+	require.Equal(t, 65*time.Second, read[1].Duration)
+	require.Equal(t, "", read[1].Destination)
+}
+
+func TestCSV_Errors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.EqualError(t, NewCSVWriter(buf, Options{Columns: []Column{"cost"}}).Write(v1.CDR{}), `cdrexport: unknown column "cost"`)
+	require.EqualError(t, NewCSVWriter(buf, Options{Columns: []Column{ColumnDate, ColumnDate}}).Close(), `cdrexport: column "date" given twice`)
+
+	_, err := ReadAll(NewCSVReader(strings.NewReader("uniqueid,total\n1,0.01\n2,abc\n"), Options{}))
+	require.EqualError(t, err, `line 3: cdrexport: total: voipms: "abc" is not an amount of money`)
+}
+
+func TestJSONL_RoundTrip(t *testing.T) {
+
+	//setup
+	cdrs := testCDRs(t, 3)
+	buf := &bytes.Buffer{}
+
+	//execute
+	write(t, NewJSONLWriter(buf, Options{Duration: DurationGo}), cdrs)
+	read, err := ReadAll(NewJSONLReader(bytes.NewReader(buf.Bytes()), Options{Duration: DurationGo}))
+
+	//verify
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, `{"date":"2020-03-08T10:01:05-06:00","callerid":"\"Jane, Doe\" <15554443333>","destination":"15554441111","description":"Inbound DID","account":"100000_office","disposition":"ANSWERED","duration":"1m5s","seconds":65,"rate":"0.0090","total":"0.0018","uniqueid":"982384591"}`, lines[1])
+	requireSameCDRs(t, cdrs, read)
+}
+
+func TestColumnar_RoundTrip(t *testing.T) {
+
+	//setup
+	cdrs := testCDRs(t, 5)
+	buf := &bytes.Buffer{}
+	opts := Options{Columns: []Column{ColumnDate, ColumnSeconds, ColumnDuration, ColumnTotal}, Duration: DurationSeconds}
+
+	//execute
+	write(t, NewColumnarWriter(buf, opts, 2), cdrs)
+	read, err := ReadAll(NewColumnarReader(bytes.NewReader(buf.Bytes()), opts))
+
+	//verify
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, `{"format":"cdrexport-columnar/1","columns":["date","seconds","duration","total"]}`, lines[0])
+	require.Equal(t, `{"rows":2,"date":["2020-03-08T10:00:05-06:00","2020-03-08T10:01:05-06:00"],"seconds":[5,65],"duration":[5,65],"total":["0.0009","0.0018"]}`, lines[1])
+	require.Equal(t, `{"rows":1,"date":["2020-03-08T10:04:05-06:00"],"seconds":[245],"duration":[245],"total":["0.0045"]}`, lines[3])
+	require.Len(t, read, 5)
+	require.Equal(t, v1.MustParseMoney("0.0045"), read[4].Total)
+	require.Equal(t, 245*time.Second, read[4].Duration)
+
+	_, err = ReadAll(NewColumnarReader(strings.NewReader(`{"format":"parquet"}`), opts))
+	require.EqualError(t, err, `cdrexport: not a columnar file, format is "parquet"`)
+}
+
+func TestColumnar_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, NewColumnarWriter(buf, Options{}, 0).Close())
+
+	read, err := ReadAll(NewColumnarReader(bytes.NewReader(buf.Bytes()), Options{}))
+	require.NoError(t, err)
+	require.Len(t, read, 0)
+}
+
+func TestCopy(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		fmt.Fprintf(w, `{"status":"success","cdr":[{"date":"%s 10:00:00","duration":"00:01:00","seconds":"60","total":"0.01000000","uniqueid":"%s"}]}`, r.Form.Get("date_from"), r.Form.Get("date_from"))
+	}))
+	defer ts.Close()
+
+	api := v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	it := api.Query(context.Background(), v1.CDRQuery{
+		From:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		WindowDays: 1,
+	})
+	buf := &bytes.Buffer{}
+
+	//execute
+	n, err := Copy(NewCSVWriter(buf, Options{Columns: []Column{ColumnUniqueID, ColumnDuration, ColumnTotal}}), it)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, "uniqueid,duration,total\n2020-01-01,00:01:00,0.0100\n2020-01-02,00:01:00,0.0100\n2020-01-03,00:01:00,0.0100\n", buf.String())
+}
+
+func TestCopy_Error(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("date_from") == "2020-01-02" {
+			fmt.Fprintln(w, `{"status":"limit_reached"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","cdr":[{"date":"%s 10:00:00","duration":"00:01:00","total":"0.01","uniqueid":"1"}]}`, r.Form.Get("date_from"))
+	}))
+	defer ts.Close()
+
+	it := v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI().Query(context.Background(), v1.CDRQuery{
+		From:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		WindowDays: 1,
+	})
+	buf := &bytes.Buffer{}
+
+	//execute
+	n, err := Copy(NewColumnarWriter(buf, Options{Columns: []Column{ColumnUniqueID}}, 0), it)
+
+	//verify
+	require.True(t, errors.Is(err, v1.ErrRateLimited), err)
+	require.Equal(t, 1, n)
+	require.Equal(t, `{"format":"cdrexport-columnar/1","columns":["uniqueid"]}`+"\n"+`{"rows":1,"uniqueid":["1"]}`+"\n", buf.String())
+}
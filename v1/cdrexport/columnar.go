@@ -0,0 +1,188 @@
+package cdrexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Rows per group when NewColumnarWriter is given 0.
+const DefaultRowGroupSize = 10000
+
+//Identifies the header line of a columnar file.
+const columnarFormat = "cdrexport-columnar/1"
+
+//Writes CDRs column by column without needing a Parquet library. The file is JSON Lines: a header naming the
+//columns, then one line per row group holding an array of values for each column:
+//
+//	{"format":"cdrexport-columnar/1","columns":["date","total"]}
+//	{"rows":2,"date":["2020-01-01T10:00:00Z","2020-01-01T10:05:00Z"],"total":["0.0100","0.0250"]}
+//
+//Values are encoded as in JSONLWriter. Only one row group is held in memory.
+type ColumnarWriter struct {
+	w       *bufio.Writer
+	opts    Options
+	size    int
+	header  bool
+	columns [][]json.RawMessage
+	rows    int
+	line    bytes.Buffer
+}
+
+func NewColumnarWriter(w io.Writer, opts Options, rowGroupSize int) *ColumnarWriter {
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+	return &ColumnarWriter{w: bufio.NewWriter(w), opts: opts, size: rowGroupSize}
+}
+
+func (w *ColumnarWriter) Write(cdr v1.CDR) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	for i, col := range w.opts.columns() {
+		w.columns[i] = append(w.columns[i], w.opts.formatJSON(cdr, col))
+	}
+	w.rows++
+
+	if w.rows >= w.size {
+		return w.flushGroup()
+	}
+	return nil
+}
+
+func (w *ColumnarWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+
+	if err := w.opts.validate(); err != nil {
+		return err
+	}
+
+	header, err := json.Marshal(columnarHeader{Format: columnarFormat, Columns: w.opts.columns()})
+	if err != nil {
+		return err
+	}
+	w.header = true
+	w.columns = make([][]json.RawMessage, len(w.opts.columns()))
+
+	_, err = w.w.Write(append(header, '\n'))
+	return err
+}
+
+func (w *ColumnarWriter) flushGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	w.line.Reset()
+	fmt.Fprintf(&w.line, `{"rows":%d`, w.rows)
+	for i, col := range w.opts.columns() {
+		fmt.Fprintf(&w.line, ",%q:[", col)
+		for j, v := range w.columns[i] {
+			if j > 0 {
+				w.line.WriteByte(',')
+			}
+			w.line.Write(v)
+		}
+		w.line.WriteByte(']')
+		w.columns[i] = w.columns[i][:0]
+	}
+	w.line.WriteString("}\n")
+	w.rows = 0
+
+	_, err := w.w.Write(w.line.Bytes())
+	return err
+}
+
+//Writes the last row group and flushes.
+func (w *ColumnarWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if err := w.flushGroup(); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+type columnarHeader struct {
+	Format  string   `json:"format"`
+	Columns []Column `json:"columns"`
+}
+
+//Reads files written by ColumnarWriter, a row group at a time.
+type ColumnarReader struct {
+	dec     *json.Decoder
+	opts    Options
+	columns []Column
+	group   map[string][]json.RawMessage
+	rows    int
+	row     int
+}
+
+func NewColumnarReader(r io.Reader, opts Options) *ColumnarReader {
+	return &ColumnarReader{dec: json.NewDecoder(r), opts: opts}
+}
+
+func (r *ColumnarReader) Read() (v1.CDR, error) {
+	if r.columns == nil {
+		header := columnarHeader{}
+		if err := r.dec.Decode(&header); err != nil {
+			return v1.CDR{}, err
+		}
+		if header.Format != columnarFormat {
+			return v1.CDR{}, fmt.Errorf("cdrexport: not a columnar file, format is %q", header.Format)
+		}
+		r.columns = header.Columns
+	}
+
+	for r.row >= r.rows {
+		if err := r.readGroup(); err != nil {
+			return v1.CDR{}, err
+		}
+	}
+
+	cdr := v1.CDR{}
+	for _, col := range r.columns {
+		if err := r.opts.parseJSON(&cdr, col, r.group[string(col)][r.row]); err != nil {
+			return v1.CDR{}, err
+		}
+	}
+	r.row++
+
+	return cdr, nil
+}
+
+func (r *ColumnarReader) readGroup() error {
+	raw := map[string]json.RawMessage{}
+	if err := r.dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	rows := 0
+	if err := json.Unmarshal(raw["rows"], &rows); err != nil {
+		return fmt.Errorf("cdrexport: row group: %w", err)
+	}
+
+	group := map[string][]json.RawMessage{}
+	for _, col := range r.columns {
+		values := []json.RawMessage{}
+		if err := json.Unmarshal(raw[string(col)], &values); err != nil {
+			return fmt.Errorf("cdrexport: row group %s: %w", col, err)
+		}
+		if len(values) != rows {
+			return fmt.Errorf("cdrexport: row group %s has %d values, expected %d", col, len(values), rows)
+		}
+		group[string(col)] = values
+	}
+
+	r.group, r.rows, r.row = group, rows, 0
+	return nil
+}
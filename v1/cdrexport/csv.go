@@ -0,0 +1,104 @@
+package cdrexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Writes CDRs as CSV with a header row of column names.
+type CSVWriter struct {
+	w      *csv.Writer
+	opts   Options
+	header bool
+	row    []string
+}
+
+func NewCSVWriter(w io.Writer, opts Options) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), opts: opts}
+}
+
+func (w *CSVWriter) Write(cdr v1.CDR) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.row = w.row[:0]
+	for _, col := range w.opts.columns() {
+		w.row = append(w.row, w.opts.format(cdr, col))
+	}
+
+	return w.w.Write(w.row)
+}
+
+func (w *CSVWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+
+	if err := w.opts.validate(); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, col := range w.opts.columns() {
+		names = append(names, string(col))
+	}
+	w.header = true
+
+	return w.w.Write(names)
+}
+
+//Writes the header if nothing else was and flushes.
+func (w *CSVWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+//Reads CSV written by CSVWriter. Columns come from the header row, Options.Columns is ignored.
+type CSVReader struct {
+	r       *csv.Reader
+	opts    Options
+	columns []Column
+}
+
+func NewCSVReader(r io.Reader, opts Options) *CSVReader {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSVReader{r: cr, opts: opts}
+}
+
+func (r *CSVReader) Read() (v1.CDR, error) {
+	if r.columns == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			return v1.CDR{}, err
+		}
+		for _, name := range header {
+			if !isColumn(Column(name)) {
+				return v1.CDR{}, fmt.Errorf("cdrexport: unknown column %q", name)
+			}
+			r.columns = append(r.columns, Column(name))
+		}
+	}
+
+	row, err := r.r.Read()
+	if err != nil {
+		return v1.CDR{}, err
+	}
+
+	cdr := v1.CDR{}
+	for i, col := range r.columns {
+		if err := r.opts.parse(&cdr, col, row[i]); err != nil {
+			line, _ := r.r.FieldPos(i)
+			return v1.CDR{}, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return cdr, nil
+}
@@ -0,0 +1,91 @@
+package cdrexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Writes CDRs as JSON Lines, one object per CDR with the columns as keys in order. Seconds, and the duration when
+//written as DurationSeconds, are numbers. Everything else is a string so money keeps its exact value.
+type JSONLWriter struct {
+	w     *bufio.Writer
+	opts  Options
+	valid bool
+	line  bytes.Buffer
+}
+
+func NewJSONLWriter(w io.Writer, opts Options) *JSONLWriter {
+	return &JSONLWriter{w: bufio.NewWriter(w), opts: opts}
+}
+
+func (w *JSONLWriter) Write(cdr v1.CDR) error {
+	if !w.valid {
+		if err := w.opts.validate(); err != nil {
+			return err
+		}
+		w.valid = true
+	}
+
+	w.line.Reset()
+	w.line.WriteByte('{')
+	for i, col := range w.opts.columns() {
+		if i > 0 {
+			w.line.WriteByte(',')
+		}
+		fmt.Fprintf(&w.line, "%q:", col)
+		w.line.Write(w.opts.formatJSON(cdr, col))
+	}
+	w.line.WriteString("}\n")
+
+	_, err := w.w.Write(w.line.Bytes())
+	return err
+}
+
+func (w *JSONLWriter) Close() error {
+	return w.w.Flush()
+}
+
+//Reads JSON Lines written by JSONLWriter. Keys that aren't columns are an error, missing ones are left zero.
+type JSONLReader struct {
+	s    *bufio.Scanner
+	opts Options
+	line int
+}
+
+func NewJSONLReader(r io.Reader, opts Options) *JSONLReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &JSONLReader{s: s, opts: opts}
+}
+
+func (r *JSONLReader) Read() (v1.CDR, error) {
+	for r.s.Scan() {
+		r.line++
+		if len(bytes.TrimSpace(r.s.Bytes())) == 0 {
+			continue
+		}
+
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(r.s.Bytes(), &fields); err != nil {
+			return v1.CDR{}, fmt.Errorf("line %d: %w", r.line, err)
+		}
+
+		cdr := v1.CDR{}
+		for name, raw := range fields {
+			if err := r.opts.parseJSON(&cdr, Column(name), raw); err != nil {
+				return v1.CDR{}, fmt.Errorf("line %d: %w", r.line, err)
+			}
+		}
+		return cdr, nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return v1.CDR{}, err
+	}
+	return v1.CDR{}, io.EOF
+}
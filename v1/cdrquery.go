@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//Days fetched per request when CDRQuery.WindowDays isn't set.
+const DefaultCDRWindowDays = 7
+
+//Selects CDRs for CDRAPI.Query. Only the dates of From and To are used, both are inclusive.
+type CDRQuery struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location //Timezone call dates are returned in. Required. Days are split at the offset it has on From.
+
+	Status      CallStatus
+	CallType    string
+	CallBilling string
+	Account     string
+	Client      string //Reseller client. Set to query getResellerCDR instead of getCDR.
+
+	WindowDays  int //Days fetched per request, DefaultCDRWindowDays when 0. Use 1 for busy accounts.
+	Concurrency int //Windows fetched at once, 1 when 0. Records are still returned in date order.
+
+	Checkpoint *CDRCheckpoint //Resumes a previous query from CDRIterator.Checkpoint. The rest of the query must be unchanged.
+}
+
+//Where a CDRIterator got to. Next is the first day of the first window not completely returned and Skip the number
+//of records already returned from it.
+type CDRCheckpoint struct {
+	Next time.Time `json:"next"`
+	Skip int       `json:"skip"`
+}
+
+//Checks q for a missing or inconsistent field. The error matches ErrValidation.
+func (q CDRQuery) Validate() error {
+	switch {
+	case q.From.IsZero():
+		return fmt.Errorf("%w: CDRQuery.From is required", ErrValidation)
+	case q.To.IsZero():
+		return fmt.Errorf("%w: CDRQuery.To is required", ErrValidation)
+	case q.Location == nil:
+		return fmt.Errorf("%w: CDRQuery.Location is required", ErrValidation)
+	case q.date(q.To).Before(q.date(q.From)):
+		return fmt.Errorf("%w: CDRQuery.To is before From", ErrValidation)
+	case q.WindowDays < 0:
+		return fmt.Errorf("%w: CDRQuery.WindowDays is negative", ErrValidation)
+	case q.Concurrency < 0:
+		return fmt.Errorf("%w: CDRQuery.Concurrency is negative", ErrValidation)
+	}
+
+	if cp := q.Checkpoint; cp != nil {
+		next := q.date(cp.Next)
+		if next.Before(q.date(q.From)) || next.After(q.date(q.To).AddDate(0, 0, 1)) || cp.Skip < 0 {
+			return fmt.Errorf("%w: CDRQuery.Checkpoint is outside the query", ErrValidation)
+		}
+	}
+
+	return nil
+}
+
+//Returns midnight of t's date in q.Location, keeping the date as given rather than converting it.
+func (q CDRQuery) date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	loc := q.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+type cdrWindow struct {
+	from, to time.Time
+}
+
+//The fixed zone every window is requested in. voip.ms takes a single UTC offset per request, so using Location's
+//offset on From for all of them keeps the windows back to back across a DST change instead of gaining or losing an hour.
+func (q CDRQuery) zone() *time.Location {
+	return time.FixedZone(q.Location.String(), cdrOffset(q.date(q.From), q.Location))
+}
+
+//Splits the query into WindowDays long windows starting at From, or at the checkpoint when resuming.
+func (q CDRQuery) windows() []cdrWindow {
+	days := q.WindowDays
+	if days == 0 {
+		days = DefaultCDRWindowDays
+	}
+
+	start := q.date(q.From)
+	if q.Checkpoint != nil {
+		start = q.date(q.Checkpoint.Next)
+	}
+	end := q.date(q.To)
+
+	var windows []cdrWindow
+	for from := start; !from.After(end); {
+		to := from.AddDate(0, 0, days-1)
+		if to.After(end) {
+			to = end
+		}
+
+		windows = append(windows, cdrWindow{from, to})
+		from = to.AddDate(0, 0, 1)
+	}
+
+	return windows
+}
+
+type cdrWindowResult struct {
+	cdrs []CDR
+	err  error
+}
+
+//Streams the CDRs matched by a CDRQuery one at a time:
+//
+//	it := cdrAPI.Query(ctx, q)
+//	defer it.Close()
+//	for it.Next() {
+//		cdr := it.CDR()
+//	}
+//	if err := it.Err(); err != nil {
+//		//it.Checkpoint() can be saved and passed back in CDRQuery.Checkpoint to carry on later.
+//	}
+//
+//Only Concurrency windows are held in memory at a time. It isn't safe for concurrent use.
+type CDRIterator struct {
+	api *CDRAPI
+	q   CDRQuery
+
+	windows []cdrWindow
+	results []chan cdrWindowResult
+	slots   chan struct{}
+	cancel  context.CancelFunc
+	ctx     context.Context
+
+	started bool
+	window  int   //Index of the window being returned.
+	cdrs    []CDR //Records of the current window.
+	pos     int   //Records of the current window returned so far.
+	cdr     CDR
+	err     error
+}
+
+//Returns an iterator over the CDRs q selects. Nothing is fetched until the first call to Next. An invalid query is
+//reported by Err once Next returns false.
+func (c *CDRAPI) Query(ctx context.Context, q CDRQuery) *CDRIterator {
+	it := &CDRIterator{api: c, q: q}
+
+	if err := q.Validate(); err != nil {
+		it.err = err
+		it.started = true
+		return it
+	}
+
+	it.ctx, it.cancel = context.WithCancel(ctx)
+	it.windows = q.windows()
+	if q.Checkpoint != nil {
+		it.pos = q.Checkpoint.Skip
+	}
+
+	return it
+}
+
+//Moves to the next CDR, returning false when there are no more or an error stopped the iteration.
+func (it *CDRIterator) Next() bool {
+	if !it.started {
+		it.start()
+	}
+
+	for it.err == nil {
+		if it.cdrs != nil && it.pos < len(it.cdrs) {
+			it.cdr = it.cdrs[it.pos]
+			it.pos++
+			return true
+		}
+
+		if it.cdrs != nil {
+			//Done with the window, free its slot for the fetcher.
+			it.window++
+			it.pos = 0
+			it.cdrs = nil
+			<-it.slots
+		}
+
+		if it.window >= len(it.windows) {
+			it.Close()
+			return false
+		}
+
+		select {
+		case rs := <-it.results[it.window]:
+			if rs.err != nil {
+				it.err = rs.err
+				it.Close()
+				return false
+			}
+			it.cdrs = rs.cdrs
+			if it.cdrs == nil {
+				it.cdrs = []CDR{}
+			}
+		case <-it.ctx.Done():
+			it.err = it.ctx.Err()
+			return false
+		}
+	}
+
+	return false
+}
+
+//Fetches windows in order, at most Concurrency ahead of the one being returned.
+func (it *CDRIterator) start() {
+	it.started = true
+
+	concurrency := it.q.Concurrency
+	if concurrency == 0 {
+		concurrency = 1
+	}
+	it.slots = make(chan struct{}, concurrency)
+
+	it.results = make([]chan cdrWindowResult, len(it.windows))
+	for i := range it.results {
+		it.results[i] = make(chan cdrWindowResult, 1)
+	}
+
+	go func() {
+		for i, w := range it.windows {
+			select {
+			case it.slots <- struct{}{}:
+			case <-it.ctx.Done():
+				return
+			}
+
+			go func(i int, w cdrWindow) {
+				cdrs, err := it.api.fetchWindow(it.ctx, it.q, w)
+				it.results[i] <- cdrWindowResult{cdrs, err}
+			}(i, w)
+		}
+	}()
+}
+
+//The current CDR.
+func (it *CDRIterator) CDR() CDR {
+	return it.cdr
+}
+
+//Returns the error that ended the iteration, nil if it ran to the end or hasn't yet.
+func (it *CDRIterator) Err() error {
+	return it.err
+}
+
+//Returns where the iteration got to. Resuming from it returns the records after the current one.
+func (it *CDRIterator) Checkpoint() CDRCheckpoint {
+	if it.ctx == nil {
+		return CDRCheckpoint{}
+	}
+	if it.window >= len(it.windows) {
+		return CDRCheckpoint{Next: it.q.date(it.q.To).AddDate(0, 0, 1)}
+	}
+	return CDRCheckpoint{Next: it.windows[it.window].from, Skip: it.pos}
+}
+
+//Stops any fetches still running. Always call it when stopping early, it's done for you once Next returns false.
+func (it *CDRIterator) Close() error {
+	if it.cancel != nil {
+		it.cancel()
+	}
+	return nil
+}
+
+func (c *CDRAPI) fetchWindow(ctx context.Context, q CDRQuery, w cdrWindow) ([]CDR, error) {
+	var cdrs []CDR
+	var err error
+	if q.Client != "" {
+		cdrs, err = c.GetResellerCDRContext(ctx, w.from, w.to, q.Client, q.Status, q.zone(), q.CallType, q.CallBilling, q.Account)
+	} else {
+		cdrs, err = c.GetCDRContext(ctx, w.from, w.to, q.Status, q.zone(), q.CallType, q.CallBilling, q.Account)
+	}
+
+	//voip.ms answers no_cdr for a window without calls.
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) && apiErr.Status == "no_cdr" {
+		return []CDR{}, nil
+	}
+
+	for i := range cdrs {
+		cdrs[i].Date = cdrs[i].Date.In(q.Location)
+	}
+	return cdrs, err
+}
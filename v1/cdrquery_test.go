@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+//Answers getCDR and getResellerCDR with two calls a day, except on the 3rd, and records the windows asked for. A window
+//starting on *fail is rate limited.
+func newCDRServer(t *testing.T, windows *[]string, fail *string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := formValues(r)
+		from, _ := time.Parse("2006-01-02", values.Get("date_from"))
+		to, _ := time.Parse("2006-01-02", values.Get("date_to"))
+
+		mu.Lock()
+		*windows = append(*windows, values.Get("date_from")+"/"+values.Get("date_to")+"/"+values.Get("client"))
+		mu.Unlock()
+
+		if fail != nil && values.Get("date_from") == *fail {
+			fmt.Fprintln(w, `{"status":"limit_reached"}`)
+			return
+		}
+
+		var cdrs []string
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			if d.Day() == 3 {
+				continue
+			}
+			for i := 1; i <= 2; i++ {
+				cdrs = append(cdrs, fmt.Sprintf(`{"date":"%s 10:00:0%d","duration":"00:00:05","uniqueid":"%s-%d"}`, d.Format("2006-01-02"), i, d.Format("0102"), i))
+			}
+		}
+
+		if len(cdrs) == 0 {
+			fmt.Fprintln(w, `{"status":"no_cdr"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","cdr":[%s]}`, strings.Join(cdrs, ","))
+	}))
+}
+
+func collectCDRs(it *CDRIterator) []string {
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.CDR().UniqueId)
+	}
+	return ids
+}
+
+func TestCDRAPI_Query(t *testing.T) {
+
+	//setup
+	var windows []string
+	ts := newCDRServer(t, &windows, nil)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	q := CDRQuery{
+		From:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2020, 1, 16, 0, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+	}
+
+	//execute
+	it := api.Query(context.Background(), q)
+	ids := collectCDRs(it)
+
+	//verify
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"2020-01-01/2020-01-07/", "2020-01-08/2020-01-14/", "2020-01-15/2020-01-16/"}, windows)
+	require.Len(t, ids, 30)
+	require.Equal(t, "0101-1", ids[0])
+	require.Equal(t, "0104-1", ids[4])
+	require.Equal(t, "0116-2", ids[29])
+	require.Equal(t, CDRCheckpoint{Next: time.Date(2020, 1, 17, 0, 0, 0, 0, time.UTC)}, it.Checkpoint())
+}
+
+func TestCDRAPI_Query_Concurrency(t *testing.T) {
+
+	//setup
+	var windows []string
+	ts := newCDRServer(t, &windows, nil)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	q := CDRQuery{
+		From:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+		Location:    time.UTC,
+		Client:      "561115",
+		WindowDays:  1,
+		Concurrency: 4,
+	}
+
+	//execute
+	it := api.Query(context.Background(), q)
+	ids := collectCDRs(it)
+
+	//verify
+	require.NoError(t, it.Err())
+	require.Len(t, windows, 31)
+	require.Contains(t, windows, "2020-01-31/2020-01-31/561115")
+	require.Len(t, ids, 60)
+	for i := 1; i < len(ids); i++ {
+		require.True(t, ids[i-1] < ids[i], ids[i])
+	}
+}
+
+func TestCDRAPI_Query_Checkpoint(t *testing.T) {
+
+	//setup
+	var windows []string
+	fail := "2020-01-05"
+	ts := newCDRServer(t, &windows, &fail)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	q := CDRQuery{
+		From:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		WindowDays: 2,
+	}
+
+	//execute
+	it := api.Query(context.Background(), q)
+	first := collectCDRs(it)
+	errFirst := it.Err()
+
+	saved, err := json.Marshal(it.Checkpoint())
+	require.NoError(t, err)
+
+	fail = ""
+	windows = nil
+	resumed := q
+	resumed.Checkpoint = &CDRCheckpoint{}
+	require.NoError(t, json.Unmarshal(saved, resumed.Checkpoint))
+	second := collectCDRs(api.Query(context.Background(), resumed))
+
+	it = api.Query(context.Background(), q)
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	require.True(t, it.Next())
+	cp := it.Checkpoint()
+	it.Close()
+
+	resumed.Checkpoint = &cp
+	third := collectCDRs(api.Query(context.Background(), resumed))
+
+	//verify
+	require.True(t, errors.Is(errFirst, ErrRateLimited))
+	require.Equal(t, []string{"0101-1", "0101-2", "0102-1", "0102-2", "0104-1", "0104-2"}, first)
+	require.Equal(t, `{"next":"2020-01-05T00:00:00Z","skip":0}`, string(saved))
+	require.Equal(t, []string{"0105-1", "0105-2", "0106-1", "0106-2"}, second)
+	require.Equal(t, "2020-01-05/2020-01-06/", windows[0])
+	require.Equal(t, CDRCheckpoint{Next: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Skip: 3}, cp)
+	require.Equal(t, []string{"0102-2", "0104-1", "0104-2", "0105-1", "0105-2", "0106-1", "0106-2"}, third)
+}
+
+func TestCDRAPI_Query_Cancelled(t *testing.T) {
+
+	//setup
+	var windows []string
+	ts := newCDRServer(t, &windows, nil)
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	ctx, cancel := context.WithCancel(context.Background())
+	q := CDRQuery{
+		From:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+	}
+
+	//execute
+	it := api.Query(ctx, q)
+	require.True(t, it.Next())
+	cancel()
+	for it.Next() {
+	}
+
+	//verify
+	require.True(t, errors.Is(it.Err(), context.Canceled))
+	require.True(t, len(windows) < 9)
+}
+
+func TestCDRQuery_windows(t *testing.T) {
+
+	//setup
+	mst, _ := time.LoadLocation("America/Edmonton")
+	q := CDRQuery{
+		From:     time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2020, 3, 20, 0, 0, 0, 0, time.UTC),
+		Location: mst,
+	}
+
+	//execute
+	windows := q.windows()
+
+	//verify
+	var got []string
+	for _, w := range windows {
+		got = append(got, w.from.Format("01-02")+"/"+w.to.Format("01-02"))
+	}
+	require.Equal(t, []string{"03-05/03-11", "03-12/03-18", "03-19/03-20"}, got)
+	_, offset := time.Date(2020, 3, 20, 0, 0, 0, 0, q.zone()).Zone()
+	require.Equal(t, -7*3600, offset) //Edmonton's offset on the 5th, not the 20th
+}
+
+func TestCDRAPI_Query_DST(t *testing.T) {
+
+	//setup, a call every hour, selected and dated at the offset asked for like voip.ms does
+	var calls []time.Time
+	for t := time.Date(2020, 10, 29, 0, 0, 0, 0, time.UTC); t.Before(time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)); t = t.Add(time.Hour) {
+		calls = append(calls, t)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := formValues(r)
+		hours, _ := strconv.ParseFloat(values.Get("timezone"), 64)
+		zone := time.FixedZone("", int(hours*3600))
+		from, _ := time.ParseInLocation("2006-01-02", values.Get("date_from"), zone)
+		to, _ := time.ParseInLocation("2006-01-02", values.Get("date_to"), zone)
+
+		var cdrs []string
+		for _, c := range calls {
+			if !c.Before(from) && c.Before(to.AddDate(0, 0, 1)) {
+				cdrs = append(cdrs, fmt.Sprintf(`{"date":"%s","uniqueid":"%s"}`, c.In(zone).Format("2006-01-02 15:04:05"), c.Format(time.RFC3339)))
+			}
+		}
+		fmt.Fprintf(w, `{"status":"success","cdr":[%s]}`, strings.Join(cdrs, ","))
+	}))
+	defer ts.Close()
+
+	toronto, _ := time.LoadLocation("America/Toronto")
+	it := NewVOIPClient(ts.URL, "", "").NewCDRAPI().Query(context.Background(), CDRQuery{
+		From:       time.Date(2020, 10, 30, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC),
+		Location:   toronto,
+		WindowDays: 2,
+	})
+
+	//execute
+	var got []CDR
+	for it.Next() {
+		got = append(got, it.CDR())
+	}
+
+	//verify, every hour from midnight on the 30th at -4 once and in order
+	require.NoError(t, it.Err())
+	require.Len(t, got, 5*24)
+	for i, cdr := range got {
+		want := time.Date(2020, 10, 30, 4, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour)
+		require.Equal(t, want.Format(time.RFC3339), cdr.UniqueId)
+		require.True(t, want.Equal(cdr.Date), cdr.UniqueId)
+		require.Equal(t, toronto, cdr.Date.Location())
+	}
+}
+
+func TestCDRQuery_Validate(t *testing.T) {
+	from := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	valid := CDRQuery{From: from, To: from, Location: time.UTC}
+
+	require.NoError(t, valid.Validate())
+
+	for field, q := range map[string]CDRQuery{
+		"From is required":          {To: from, Location: time.UTC},
+		"To is required":            {From: from, Location: time.UTC},
+		"Location is required":      {From: from, To: from},
+		"To is before From":         {From: from, To: from.AddDate(0, 0, -1), Location: time.UTC},
+		"WindowDays is negative":    {From: from, To: from, Location: time.UTC, WindowDays: -1},
+		"Checkpoint is outside the": {From: from, To: from, Location: time.UTC, Checkpoint: &CDRCheckpoint{Next: from.AddDate(0, 0, -1)}},
+	} {
+		err := q.Validate()
+		require.True(t, errors.Is(err, ErrValidation), field)
+		require.Contains(t, err.Error(), field)
+	}
+
+	it := NewVOIPClient("http://localhost:1", "", "").NewCDRAPI().Query(context.Background(), CDRQuery{})
+	require.False(t, it.Next())
+	require.True(t, errors.Is(it.Err(), ErrValidation))
+}
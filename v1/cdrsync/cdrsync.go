@@ -0,0 +1,206 @@
+//Package cdrsync mirrors CDRs into a local store. Each source, an account's own CDRs or a reseller client's, has a
+//watermark: the time it was last synced up to. A sync fetches from a trailing window before the watermark to now, so
+//records voip.ms adds or changes late are picked up, and upserts them by UniqueId:
+//
+//	store, err := cdrsync.OpenFileStore("cdrs")
+//	s := cdrsync.New(client.NewCDRAPI(), store, cdrsync.Options{Location: loc, Since: firstDay})
+//	result, err := s.Sync(ctx, cdrsync.Source{Account: "100000_office"})
+//	fmt.Println(result.Inserted, result.Updated)
+//
+//The watermark only moves once a sync completes, so a failed sync is simply run again.
+package cdrsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//How far before the watermark a sync starts when Options.Trailing isn't set.
+const DefaultTrailing = 48 * time.Hour
+
+//CDRs per Store.Upsert when Options.BatchSize isn't set.
+const DefaultBatchSize = 500
+
+//Where synced CDRs are kept. Keys come from Source.Key.
+type Store interface {
+	//Saves cdrs, replacing any stored under key with the same UniqueId.
+	Upsert(ctx context.Context, key string, cdrs []v1.CDR) (Counts, error)
+	//Returns the time key was last synced up to, zero if never.
+	Watermark(ctx context.Context, key string) (time.Time, error)
+	SetWatermark(ctx context.Context, key string, t time.Time) error
+}
+
+//What an upsert did with each CDR.
+type Counts struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+func (c *Counts) add(o Counts) {
+	c.Inserted += o.Inserted
+	c.Updated += o.Updated
+	c.Unchanged += o.Unchanged
+}
+
+//A set of CDRs synced and watermarked together.
+type Source struct {
+	Client  string //Reseller client, fetched with getResellerCDR. Empty for the account's own CDRs.
+	Account string //Sub account, i.e. 100000_office. Empty for all of them.
+}
+
+//Names the source in a Store, i.e. "all", "100000_office", "client/12345" or "client/12345/100000_office".
+func (s Source) Key() string {
+	key := s.Account
+	if s.Client != "" {
+		key = "client/" + s.Client
+		if s.Account != "" {
+			key += "/" + s.Account
+		}
+	}
+	if key == "" {
+		key = "all"
+	}
+	return key
+}
+
+//A Source for each reseller client, i.e. from ClientsAPI.GetClients.
+func ClientSources(clients []v1.Client) []Source {
+	sources := make([]Source, len(clients))
+	for i, c := range clients {
+		sources[i] = Source{Client: c.Client}
+	}
+	return sources
+}
+
+type Options struct {
+	Location *time.Location //Timezone CDRs are requested in. Required.
+	Since    time.Time      //Where a source without a watermark starts. Today when zero.
+	Trailing time.Duration  //Re-fetched before the watermark to catch late records. DefaultTrailing when 0.
+
+	Status      v1.CallStatus //Calls synced. All of them when empty.
+	WindowDays  int           //As CDRQuery.WindowDays.
+	Concurrency int           //As CDRQuery.Concurrency.
+	BatchSize   int           //CDRs per Store.Upsert. DefaultBatchSize when 0.
+}
+
+type Result struct {
+	Source    Source    `json:"source"`
+	From      time.Time `json:"from"` //First day fetched.
+	To        time.Time `json:"to"`   //Last day fetched.
+	Fetched   int       `json:"fetched"`
+	Watermark time.Time `json:"watermark"` //Set once the sync completes.
+	Counts
+}
+
+type Syncer struct {
+	api   *v1.CDRAPI
+	store Store
+	opts  Options
+	now   func() time.Time
+}
+
+func New(api *v1.CDRAPI, store Store, opts Options) *Syncer {
+	if opts.Trailing <= 0 {
+		opts.Trailing = DefaultTrailing
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Status == (v1.CallStatus{}) {
+		opts.Status = v1.CallStatus{Answered: true, NoAnswer: true, Busy: true, Failed: true}
+	}
+	return &Syncer{api: api, store: store, opts: opts, now: time.Now}
+}
+
+//Fetches src from its watermark less the trailing window, or Options.Since the first time, up to now.
+func (s *Syncer) Sync(ctx context.Context, src Source) (Result, error) {
+	result := Result{Source: src}
+	if s.opts.Location == nil {
+		return result, errors.New("cdrsync: Options.Location is required")
+	}
+
+	key := src.Key()
+	mark, err := s.store.Watermark(ctx, key)
+	if err != nil {
+		return result, err
+	}
+
+	now := s.now().In(s.opts.Location)
+	from := s.opts.Since
+	if !mark.IsZero() {
+		from = mark.Add(-s.opts.Trailing)
+	}
+	if from.IsZero() || from.After(now) {
+		from = now
+	}
+	result.From, result.To = day(from.In(s.opts.Location)), day(now)
+
+	it := s.api.Query(ctx, v1.CDRQuery{
+		From:        result.From,
+		To:          result.To,
+		Location:    s.opts.Location,
+		Status:      s.opts.Status,
+		Account:     src.Account,
+		Client:      src.Client,
+		WindowDays:  s.opts.WindowDays,
+		Concurrency: s.opts.Concurrency,
+	})
+	defer it.Close()
+
+	batch := make([]v1.CDR, 0, s.opts.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		counts, err := s.store.Upsert(ctx, key, batch)
+		result.add(counts)
+		batch = batch[:0]
+		return err
+	}
+
+	for it.Next() {
+		result.Fetched++
+		batch = append(batch, it.CDR())
+		if len(batch) >= s.opts.BatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return result, err
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	if err := s.store.SetWatermark(ctx, key, now); err != nil {
+		return result, err
+	}
+	result.Watermark = now
+
+	return result, nil
+}
+
+//Syncs each source in turn, stopping at the first error. The results cover the sources synced before it.
+func (s *Syncer) SyncAll(ctx context.Context, sources []Source) ([]Result, error) {
+	var results []Result
+	for _, src := range sources {
+		result, err := s.Sync(ctx, src)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//Midnight of t's date in its own location.
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
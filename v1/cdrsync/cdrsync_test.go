@@ -0,0 +1,212 @@
+package cdrsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+//Answers getCDR and getResellerCDR from calls, keyed by date, and records the requests made.
+type cdrServer struct {
+	mu       sync.Mutex
+	calls    map[string][]string
+	requests []url.Values
+}
+
+func newCDRServer() (*cdrServer, *httptest.Server) {
+	s := &cdrServer{calls: map[string][]string{}}
+	return s, httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, r.Form)
+
+		from, _ := time.Parse("2006-01-02", r.Form.Get("date_from"))
+		to, _ := time.Parse("2006-01-02", r.Form.Get("date_to"))
+		var cdrs []string
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			cdrs = append(cdrs, s.calls[d.Format("2006-01-02")]...)
+		}
+
+		if len(cdrs) == 0 {
+			fmt.Fprintln(w, `{"status":"no_cdr"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","cdr":[%s]}`, strings.Join(cdrs, ","))
+	}))
+}
+
+func (s *cdrServer) add(date, id, total string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[date] = append(s.calls[date], fmt.Sprintf(`{"date":"%s 10:00:00","account":"100000_office","duration":"00:01:00","seconds":"60","total":"%s","uniqueid":"%s"}`, date, total, id))
+}
+
+func (s *cdrServer) windows() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var windows []string
+	for _, r := range s.requests {
+		windows = append(windows, r.Get("method")+" "+r.Get("date_from")+"/"+r.Get("date_to")+" "+r.Get("client"))
+	}
+	s.requests = nil
+	return windows
+}
+
+func TestSync(t *testing.T) {
+
+	//setup
+	server, ts := newCDRServer()
+	defer ts.Close()
+	server.add("2020-01-01", "1", "0.01")
+	server.add("2020-01-02", "2", "0.01")
+	server.add("2020-01-04", "3", "0.01")
+
+	dir := t.TempDir()
+	store, err := OpenFileStore(dir)
+	require.NoError(t, err)
+
+	api := v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	s := New(api, store, Options{Location: time.UTC, Since: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), WindowDays: 2})
+	s.now = func() time.Time { return time.Date(2020, 1, 4, 12, 0, 0, 0, time.UTC) }
+	src := Source{Account: "100000_office"}
+
+	//execute
+	first, err := s.Sync(context.Background(), src)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, []string{"getCDR 2020-01-01/2020-01-02 ", "getCDR 2020-01-03/2020-01-04 "}, server.windows())
+	require.Equal(t, 3, first.Fetched)
+	require.Equal(t, Counts{Inserted: 3}, first.Counts)
+	require.Equal(t, s.now(), first.Watermark)
+
+	//setup, a late call and a re-rated one inside the trailing window
+	server.calls["2020-01-04"] = nil
+	server.add("2020-01-03", "4", "0.02")
+	server.add("2020-01-04", "3", "0.05")
+	s.now = func() time.Time { return time.Date(2020, 1, 5, 12, 0, 0, 0, time.UTC) }
+
+	//execute
+	second, err := s.Sync(context.Background(), src)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, []string{"getCDR 2020-01-02/2020-01-03 ", "getCDR 2020-01-04/2020-01-05 "}, server.windows())
+	require.Equal(t, Counts{Inserted: 1, Updated: 1, Unchanged: 1}, second.Counts)
+	require.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), second.From)
+
+	//verify, everything survives reopening the store
+	reopened, err := OpenFileStore(dir)
+	require.NoError(t, err)
+	mark, err := reopened.Watermark(context.Background(), "100000_office")
+	require.NoError(t, err)
+	require.True(t, s.now().Equal(mark))
+
+	cdrs, err := reopened.CDRs("100000_office")
+	require.NoError(t, err)
+	require.Len(t, cdrs, 4)
+	require.Equal(t, "3", cdrs[3].UniqueId)
+	require.Equal(t, "0.0500", cdrs[3].Total.String())
+	require.Equal(t, time.Minute, cdrs[3].Duration)
+	require.True(t, time.Date(2020, 1, 4, 10, 0, 0, 0, time.UTC).Equal(cdrs[3].Date))
+}
+
+func TestSync_Reseller(t *testing.T) {
+
+	//setup
+	server, ts := newCDRServer()
+	defer ts.Close()
+	server.add("2020-01-04", "1", "0.01")
+
+	store, err := OpenFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	api := v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI()
+	s := New(api, store, Options{Location: time.UTC})
+	s.now = func() time.Time { return time.Date(2020, 1, 4, 12, 0, 0, 0, time.UTC) }
+	sources := ClientSources([]v1.Client{{Client: "111"}, {Client: "222"}})
+
+	//execute
+	results, err := s.SyncAll(context.Background(), sources)
+
+	//verify
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, []string{"getResellerCDR 2020-01-04/2020-01-04 111", "getResellerCDR 2020-01-04/2020-01-04 222"}, server.windows())
+	require.Equal(t, 1, results[1].Inserted)
+
+	cdrs, err := store.CDRs("client/222")
+	require.NoError(t, err)
+	require.Len(t, cdrs, 1)
+}
+
+func TestSync_Error(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"invalid_client"}`)
+	}))
+	defer ts.Close()
+
+	store, err := OpenFileStore(t.TempDir())
+	require.NoError(t, err)
+	s := New(v1.NewVOIPClient(ts.URL, "", "").NewCDRAPI(), store, Options{Location: time.UTC})
+
+	//execute
+	_, err = s.Sync(context.Background(), Source{Client: "111"})
+
+	//verify
+	require.Error(t, err)
+	mark, _ := store.Watermark(context.Background(), "client/111")
+	require.True(t, mark.IsZero())
+
+	_, err = New(nil, store, Options{}).Sync(context.Background(), Source{})
+	require.EqualError(t, err, "cdrsync: Options.Location is required")
+}
+
+func TestSource_Key(t *testing.T) {
+	require.Equal(t, "all", Source{}.Key())
+	require.Equal(t, "100000_office", Source{Account: "100000_office"}.Key())
+	require.Equal(t, "client/111", Source{Client: "111"}.Key())
+	require.Equal(t, "client/111/100000_office", Source{Client: "111", Account: "100000_office"}.Key())
+}
+
+func TestFileStore_Upsert(t *testing.T) {
+
+	//setup
+	store, err := OpenFileStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	cdr := v1.CDR{Date: time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC), Total: v1.MustParseMoney("0.01"), UniqueId: "1"}
+	changed := cdr
+	changed.Total = v1.MustParseMoney("0.02")
+
+	//execute
+	first, err := store.Upsert(ctx, "all", []v1.CDR{cdr, changed, cdr})
+	require.NoError(t, err)
+	second, err := store.Upsert(ctx, "all", []v1.CDR{cdr})
+	require.NoError(t, err)
+	_, badErr := store.Upsert(ctx, "all", []v1.CDR{{Date: cdr.Date}})
+
+	//verify
+	require.Equal(t, Counts{Inserted: 1, Updated: 2}, first)
+	require.Equal(t, Counts{Unchanged: 1}, second)
+	require.EqualError(t, badErr, "cdrsync: CDR at 2020-01-02 10:00:00 +0000 UTC has no UniqueId")
+
+	require.NoError(t, store.Compact("all"))
+	cdrs, err := store.CDRs("all")
+	require.NoError(t, err)
+	require.Len(t, cdrs, 1)
+	require.Equal(t, "0.0100", cdrs[0].Total.String())
+}
@@ -0,0 +1,250 @@
+package cdrsync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+const watermarksFile = "watermarks.json"
+
+//A Store in a directory. Each source's CDRs are appended to a JSON Lines file named after its key, a later line
+//replacing an earlier one with the same UniqueId, and watermarks are kept in watermarks.json. A source's records are
+//held in memory once it's used, so it suits a single process mirroring up to a few million CDRs.
+type FileStore struct {
+	mu         sync.Mutex
+	dir        string
+	sources    map[string]map[string][]byte //Encoded CDRs by UniqueId, per key.
+	watermarks map[string]time.Time
+}
+
+//Opens the store in dir, creating it if needed.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{dir: dir, sources: map[string]map[string][]byte{}, watermarks: map[string]time.Time{}}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, watermarksFile))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &s.watermarks); err != nil {
+		return nil, fmt.Errorf("cdrsync: %s: %w", watermarksFile, err)
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".jsonl")
+}
+
+//Returns key's records, reading its file the first time.
+func (s *FileStore) load(key string) (map[string][]byte, error) {
+	if records, ok := s.sources[key]; ok {
+		return records, nil
+	}
+
+	records := map[string][]byte{}
+	f, err := os.Open(s.path(key))
+	if os.IsNotExist(err) {
+		s.sources[key] = records
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		cdr := v1.CDR{}
+		if err := json.Unmarshal(scanner.Bytes(), &cdr); err != nil {
+			return nil, fmt.Errorf("cdrsync: %s line %d: %w", s.path(key), line, err)
+		}
+		records[cdr.UniqueId] = append([]byte(nil), scanner.Bytes()...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	s.sources[key] = records
+	return records, nil
+}
+
+func (s *FileStore) Upsert(ctx context.Context, key string, cdrs []v1.CDR) (Counts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := Counts{}
+	records, err := s.load(key)
+	if err != nil {
+		return counts, err
+	}
+
+	//Validate and encode everything first so a bad CDR doesn't leave the batch half written.
+	encoded := make([][]byte, len(cdrs))
+	for i := range cdrs {
+		if cdrs[i].UniqueId == "" {
+			return counts, fmt.Errorf("cdrsync: CDR at %s has no UniqueId", cdrs[i].Date)
+		}
+		if encoded[i], err = json.Marshal(cdrs[i]); err != nil {
+			return counts, err
+		}
+	}
+
+	lines := &bytes.Buffer{}
+	changed := map[string][]byte{}
+	for i, b := range encoded {
+		id := cdrs[i].UniqueId
+		old, ok := changed[id]
+		if !ok {
+			old, ok = records[id]
+		}
+
+		switch {
+		case !ok:
+			counts.Inserted++
+		case bytes.Equal(old, b):
+			counts.Unchanged++
+			continue
+		default:
+			counts.Updated++
+		}
+
+		changed[id] = b
+		lines.Write(b)
+		lines.WriteByte('\n')
+	}
+
+	if lines.Len() > 0 {
+		if err := appendFile(s.path(key), lines.Bytes()); err != nil {
+			return Counts{}, err
+		}
+	}
+	for id, b := range changed {
+		records[id] = b
+	}
+
+	return counts, nil
+}
+
+func appendFile(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *FileStore) Watermark(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watermarks[key], nil
+}
+
+//Saves every watermark, replacing watermarks.json through a temporary file so it's never left half written.
+func (s *FileStore) SetWatermark(ctx context.Context, key string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watermarks := map[string]time.Time{key: t}
+	for k, v := range s.watermarks {
+		if k != key {
+			watermarks[k] = v
+		}
+	}
+
+	b, err := json.MarshalIndent(watermarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(s.dir, watermarksFile), append(b, '\n')); err != nil {
+		return err
+	}
+
+	s.watermarks = watermarks
+	return nil
+}
+
+//Writes path through a temporary file and a rename.
+func writeFile(path string, b []byte) error {
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+//Returns key's CDRs ordered by date then UniqueId. Dates are at the UTC offset they were synced with.
+func (s *FileStore) CDRs(key string) ([]v1.CDR, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cdrs := make([]v1.CDR, 0, len(records))
+	for _, b := range records {
+		cdr := v1.CDR{}
+		if err := json.Unmarshal(b, &cdr); err != nil {
+			return nil, err
+		}
+		cdrs = append(cdrs, cdr)
+	}
+
+	sort.Slice(cdrs, func(i, j int) bool {
+		if !cdrs[i].Date.Equal(cdrs[j].Date) {
+			return cdrs[i].Date.Before(cdrs[j].Date)
+		}
+		return cdrs[i].UniqueId < cdrs[j].UniqueId
+	})
+	return cdrs, nil
+}
+
+//Rewrites key's file with one line per CDR, dropping the lines later updates replaced.
+func (s *FileStore) Compact(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load(key)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	buf := &bytes.Buffer{}
+	for _, id := range ids {
+		buf.Write(records[id])
+		buf.WriteByte('\n')
+	}
+	return writeFile(s.path(key), buf.Bytes())
+}
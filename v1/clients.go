@@ -1,7 +1,7 @@
 package v1
 
 import (
-	"net/url"
+	"context"
 	"fmt"
 	"time"
 	"encoding/json"
@@ -17,14 +17,14 @@ type AddChargeResp struct {
 
 type AddChargeReq struct {
 	Client      string `json:"client"`
-	Charge      string `json:"charge"`
+	Charge      Money `json:"charge"`
 	Description string `json:"description"`
 	Test        string `json:"test"`
 }
 
 type AddPaymentReq struct {
 	Client      string `json:"client"`
-	Payment     string `json:"payment"`
+	Payment     Money `json:"payment"`
 	Description string `json:"description"`
 	Test        string `json:"test"`
 }
@@ -44,7 +44,7 @@ type GetChargesResp struct {
 type Charge struct {
 	Id          string `json:"id"`
 	Date        time.Time `json:"date"`
-	Amount      float64 `json:"amount"`
+	Amount      Money `json:"amount"`
 	Description string `json:"description"`
 }
 
@@ -105,7 +105,7 @@ type GetClientThresholdResp struct {
 }
 
 type ClientThreshold struct {
-	Threshold string `json:"threshold"`
+	Threshold Money `json:"threshold"`
 	Email     string `json:"email"`
 }
 
@@ -124,14 +124,14 @@ type GetPackagesResp struct {
 type Package struct {
 	Package            string `json:"package"` //This is the package id.
 	Name               string `json:"name"`
-	MarkupFixed        string `json:"markup_fixed"`
-	MarkupPercentage   string `json:"markup_percentage"`
-	Pulse              string `json:"pulse"`
+	MarkupFixed        Money `json:"markup_fixed"`
+	MarkupPercentage   FlexFloat `json:"markup_percentage"`
+	Pulse              FlexInt `json:"pulse"`
 	InternationalRoute string `json:"international_route"`
 	CanadaRoute        string `json:"canada_route"`
-	MonthlyFee         string `json:"monthly_fee"`
-	SetupFee           string `json:"setup_fee"`
-	FreeMinutes        string `json:"free_minutes"`
+	MonthlyFee         Money `json:"monthly_fee"`
+	SetupFee           Money `json:"setup_fee"`
+	FreeMinutes        FlexInt `json:"free_minutes"`
 }
 
 type GetResellerBalanceResp struct {
@@ -144,7 +144,7 @@ type SetClientReq Client
 //TODO:Stan Req objects aren't needed out side of the package. Change.
 type SetClientThresholdReq struct {
 	Client    string `json:"client"`
-	Threshold string `json:"threshold"`
+	Threshold Money `json:"threshold"`
 	Email     string `json:"email"`
 }
 
@@ -152,35 +152,43 @@ type SignupClientReq struct {
 	Client
 	ConfirmEmail    string `json:"confirm_email"`
 	ConfirmPassword string `json:"confirm_password"`
-	Activate        bool `json:"activate"`
+	Activate        bool `json:"activate" url:"activate,int"`
 }
 
-func (c *ClientsAPI) AddCharge(client, description string, charge float64, test bool) error {
+func (c *ClientsAPI) AddCharge(client, description string, charge Money, test bool) error {
+	return c.AddChargeContext(context.Background(), client, description, charge, test)
+}
+
+func (c *ClientsAPI) AddChargeContext(ctx context.Context, client, description string, charge Money, test bool) error {
 	rs := &BaseResp{}
 	rq := &AddChargeReq{
 		Client: client,
-		Charge: fmt.Sprintf("%f", charge),
+		Charge: charge,
 		Description: description,
 		Test: fmt.Sprintf("%t", test),
 	}
 
-	if err := c.client.Post("addCharge", rq, rs); err != nil {
+	if err := c.client.PostContext(ctx, "addCharge", rq, rs); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *ClientsAPI) AddPayment(client, description string, payment float64, test bool) error {
+func (c *ClientsAPI) AddPayment(client, description string, payment Money, test bool) error {
+	return c.AddPaymentContext(context.Background(), client, description, payment, test)
+}
+
+func (c *ClientsAPI) AddPaymentContext(ctx context.Context, client, description string, payment Money, test bool) error {
 	rs := &BaseResp{}
 	rq := &AddPaymentReq{
 		Client: client,
-		Payment: fmt.Sprintf("%f", payment),
+		Payment: payment,
 		Description: description,
 		Test: fmt.Sprintf("%t", test),
 	}
 
-	if err := c.client.Post("addPayment", rq, rs); err != nil {
+	if err := c.client.PostContext(ctx, "addPayment", rq, rs); err != nil {
 		return err
 	}
 
@@ -188,13 +196,16 @@ func (c *ClientsAPI) AddPayment(client, description string, payment float64, tes
 }
 
 func (c *ClientsAPI) GetBalanceManagement(balanceManagement string) ([]BalanceManagement, error) {
-	values := url.Values{}
-	if balanceManagement != "" {
-		values.Add("balance_management", balanceManagement)
-	}
+	return c.GetBalanceManagementContext(context.Background(), balanceManagement)
+}
+
+func (c *ClientsAPI) GetBalanceManagementContext(ctx context.Context, balanceManagement string) ([]BalanceManagement, error) {
+	rq := struct {
+		BalanceManagement string `url:"balance_management,omitempty"`
+	}{balanceManagement}
 
 	rs := &GetBalanceMangementResp{}
-	if err := c.client.Get("getBalanceManagement", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getBalanceManagement", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -202,11 +213,16 @@ func (c *ClientsAPI) GetBalanceManagement(balanceManagement string) ([]BalanceMa
 }
 
 func (c *ClientsAPI) GetCharges(client string) ([]Charge, error) {
-	values := url.Values{}
-	values.Add("client", client)
+	return c.GetChargesContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetChargesContext(ctx context.Context, client string) ([]Charge, error) {
+	rq := struct {
+		Client string `url:"client"`
+	}{client}
 
 	rs := &GetChargesResp{}
-	if err := c.client.Get("getCharges", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getCharges", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -214,11 +230,16 @@ func (c *ClientsAPI) GetCharges(client string) ([]Charge, error) {
 }
 
 func (c *ClientsAPI) GetClientPackages(client string) ([]ClientPackage, error) {
-	values := url.Values{}
-	values.Add("client", client)
+	return c.GetClientPackagesContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetClientPackagesContext(ctx context.Context, client string) ([]ClientPackage, error) {
+	rq := struct {
+		Client string `url:"client"`
+	}{client}
 
 	rs := &GetClientPackagesResp{}
-	if err := c.client.Get("getClientPackages", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getClientPackages", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -226,13 +247,16 @@ func (c *ClientsAPI) GetClientPackages(client string) ([]ClientPackage, error) {
 }
 
 func (c *ClientsAPI) GetClients(client string) ([]Client, error) {
-	values := url.Values{}
-	if client != "" {
-		values.Add("client", client)
-	}
+	return c.GetClientsContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetClientsContext(ctx context.Context, client string) ([]Client, error) {
+	rq := struct {
+		Client string `url:"client,omitempty"`
+	}{client}
 
 	rs := &GetClientsResp{}
-	if err := c.client.Get("getClients", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getClients", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -240,11 +264,16 @@ func (c *ClientsAPI) GetClients(client string) ([]Client, error) {
 }
 
 func (c *ClientsAPI) GetClientThreshold(client string) (*ClientThreshold, error) {
-	values := url.Values{}
-	values.Add("client", client)
+	return c.GetClientThresholdContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetClientThresholdContext(ctx context.Context, client string) (*ClientThreshold, error) {
+	rq := struct {
+		Client string `url:"client"`
+	}{client}
 
 	rs := &GetClientThresholdResp{}
-	if err := c.client.Get("getClientThreshold", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getClientThreshold", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -252,11 +281,16 @@ func (c *ClientsAPI) GetClientThreshold(client string) (*ClientThreshold, error)
 }
 
 func (c *ClientsAPI) GetDeposits(client string) ([]Deposit, error) {
-	values := url.Values{}
-	values.Add("client", client)
+	return c.GetDepositsContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetDepositsContext(ctx context.Context, client string) ([]Deposit, error) {
+	rq := struct {
+		Client string `url:"client"`
+	}{client}
 
 	rs := &GetDepositsResp{}
-	if err := c.client.Get("getDeposits", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getDeposits", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -264,13 +298,16 @@ func (c *ClientsAPI) GetDeposits(client string) ([]Deposit, error) {
 }
 
 func (c *ClientsAPI) GetPackages(packag3 string) ([]Package, error) {
-	values := url.Values{}
-	if packag3 != "" {
-		values.Add("package", packag3)
-	}
+	return c.GetPackagesContext(context.Background(), packag3)
+}
+
+func (c *ClientsAPI) GetPackagesContext(ctx context.Context, packag3 string) ([]Package, error) {
+	rq := struct {
+		Package string `url:"package,omitempty"`
+	}{packag3}
 
 	rs := &GetPackagesResp{}
-	if err := c.client.Get("getPackages", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getPackages", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -278,11 +315,16 @@ func (c *ClientsAPI) GetPackages(packag3 string) ([]Package, error) {
 }
 
 func (c *ClientsAPI) GetResellerBalance(client string) (*Balance, error) {
-	values := url.Values{}
-	values.Add("client", client)
+	return c.GetResellerBalanceContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) GetResellerBalanceContext(ctx context.Context, client string) (*Balance, error) {
+	rq := struct {
+		Client string `url:"client"`
+	}{client}
 
 	rs := &GetResellerBalanceResp{}
-	if err := c.client.Get("getResellerBalance", values, rs); err != nil {
+	if err := c.client.GetContext(ctx, "getResellerBalance", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -290,17 +332,25 @@ func (c *ClientsAPI) GetResellerBalance(client string) (*Balance, error) {
 }
 
 func (c *ClientsAPI) SetClient(client *Client) error {
+	return c.SetClientContext(context.Background(), client)
+}
+
+func (c *ClientsAPI) SetClientContext(ctx context.Context, client *Client) error {
 	rs := &BaseResp{}
 	rq := *client
 
-	if err := c.client.Post("setClient", rq, rs); err != nil {
+	if err := c.client.PostContext(ctx, "setClient", rq, rs); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *ClientsAPI) SetClientThreshold(client, threshold, email string) error {
+func (c *ClientsAPI) SetClientThreshold(client string, threshold Money, email string) error {
+	return c.SetClientThresholdContext(context.Background(), client, threshold, email)
+}
+
+func (c *ClientsAPI) SetClientThresholdContext(ctx context.Context, client string, threshold Money, email string) error {
 	rs := &BaseResp{}
 	rq := &SetClientThresholdReq{
 		client,
@@ -308,7 +358,7 @@ func (c *ClientsAPI) SetClientThreshold(client, threshold, email string) error {
 		email,
 	}
 
-	if err := c.client.Post("setClientThreshold", rq, rs); err != nil {
+	if err := c.client.PostContext(ctx, "setClientThreshold", rq, rs); err != nil {
 		return err
 	}
 
@@ -316,6 +366,10 @@ func (c *ClientsAPI) SetClientThreshold(client, threshold, email string) error {
 }
 
 func (c *ClientsAPI) SignupClient(client *Client, confirmEmail, confirmPassword string, activate bool) error {
+	return c.SignupClientContext(context.Background(), client, confirmEmail, confirmPassword, activate)
+}
+
+func (c *ClientsAPI) SignupClientContext(ctx context.Context, client *Client, confirmEmail, confirmPassword string, activate bool) error {
 	rs := &BaseResp{}
 	rq := &SignupClientReq{
 		*client,
@@ -324,7 +378,7 @@ func (c *ClientsAPI) SignupClient(client *Client, confirmEmail, confirmPassword
 		activate,
 	}
 
-	if err := c.client.Post("signupClient", rq, rs); err != nil {
+	if err := c.client.PostContext(ctx, "signupClient", rq, rs); err != nil {
 		return err
 	}
 
@@ -1,7 +1,7 @@
 package v1
 
 import (
-	"net/url"
+	"context"
 	"encoding/json"
 	"strings"
 	"errors"
@@ -122,11 +122,11 @@ type Order struct {
 	FailoverNoanswer    BaseRoute `json:"failover_noanswer,omitempty"`
 	Voicemail           string `json:"voicemail,omitempty"`
 	POP                 string `json:"pop"`
-	Dialtime            json.Number `json:"dialtime"`
-	CNAM                json.Number `json:"cnam"` //1: true, 0: false.
+	Dialtime            FlexInt `json:"dialtime"`
+	CNAM                FlexBool `json:"cnam"`
 	CalleridPrefix      string `json:"callerid_prefix,omitempty"`
 	Note                string `json:"note,omitempty"`
-	BillingType         json.Number `json:"billing_type"`
+	BillingType         FlexInt `json:"billing_type"`
 	Test                bool `json:"test,omitempty"`
 }
 
@@ -278,14 +278,14 @@ type Phonebook struct {
 
 type GetPortabilityResp struct {
 	BaseResp
-	Portable string `json:"portable"`
+	Portable FlexBool `json:"portable"`
 	Plans    []Plan `json:"plans"`
 }
 
 type Plan struct {
 	Title         string `json:"title"`
-	PricePerMonth string `json:"pricePerMonth"` //Voip.MS API uses camelcase here instead of underscores for some reason.
-	PricePerMin   string `json:"pricePerMin"`
+	PricePerMonth Money `json:"pricePerMonth"` //Voip.MS API uses camelcase here instead of underscores for some reason.
+	PricePerMin   Money `json:"pricePerMin"`
 }
 
 type GetProvincesResp struct {
@@ -313,19 +313,19 @@ type DIDInfo struct {
 	ResellerAccount       string `json:"reseller_account"`
 
 	ResellerNextBilling   string `json:"reseller_next_billing"`
-	ResellerMonthly       string `json:"reseller_monthly"`
-	ResellerMinute        string `json:"reseller_minute"`
-	ResellerSetup         string `json:"reseller_setup"`
+	ResellerMonthly       Money `json:"reseller_monthly"`
+	ResellerMinute        Money `json:"reseller_minute"`
+	ResellerSetup         Money `json:"reseller_setup"`
 
-	SMSAvailable          int `json:"sms_available"`
-	SMSEnabled            string `json:"sms_enabled"`
+	SMSAvailable          FlexBool `json:"sms_available"`
+	SMSEnabled            FlexBool `json:"sms_enabled"`
 	SMSEmail              string `json:"sms_email"`
-	SMSEmailEnabled       string `json:"sms_email_enabled"`
+	SMSEmailEnabled       FlexBool `json:"sms_email_enabled"`
 	SMSForward            string `json:"sms_forward"`
-	SMSForwardEnabled     string `json:"sms_forward_enabled"`
+	SMSForwardEnabled     FlexBool `json:"sms_forward_enabled"`
 	SMSURLCallback        string `json:"sms_url_callback"`
-	SMSURLCallbackEnabled string `json:"sms_url_callback_enabled"`
-	SMSURLCallbackEntry   string `json:"sms_url_callback_retry"`
+	SMSURLCallbackEnabled FlexBool `json:"sms_url_callback_enabled"`
+	SMSURLCallbackEntry   FlexBool `json:"sms_url_callback_retry"`
 }
 
 type DelStaticMemberResp struct {
@@ -341,9 +341,9 @@ type Callback struct {
 	Callback        string `json:"callback"`
 	Description     string `json:"description"`
 	Number          string `json:"number"`
-	DelayBefore     int `json:"delay_before"`
-	ResponseTimeout int `json:"response_timeout"`
-	DigitTimeout    int `json:"digit_timeout"`
+	DelayBefore     FlexInt `json:"delay_before"`
+	ResponseTimeout FlexInt `json:"response_timeout"`
+	DigitTimeout    FlexInt `json:"digit_timeout"`
 	CalleridNumber  string `json:"callerid_number"`
 }
 
@@ -380,11 +380,11 @@ type InternationalLocations struct {
 	LocationName string `json:"location_name"`
 	Country      string `json:"country"`
 	AreaCode     string `json:"area_code"`
-	Stock        string `json:"stock"`
-	Monthly      string `json:"montly"`
-	Setup        string `json:"setup"`
-	Minute       string `json:"minute"`
-	Channels     string `json:"channels,omitempty"` //only used for GetDIDsInternationalGeographic
+	Stock        FlexInt `json:"stock"`
+	Monthly      Money `json:"montly"`
+	Setup        Money `json:"setup"`
+	Minute       Money `json:"minute"`
+	Channels     FlexInt `json:"channels,omitempty"` //only used for GetDIDsInternationalGeographic
 }
 
 type GetInternationalTypesResp struct {
@@ -401,7 +401,7 @@ type GetRateCentersResp struct {
 
 type RateCenter struct {
 	RateCenter string `json:"ratecenter"`
-	Available  bool `json:"available"`
+	Available  FlexBool `json:"available"`
 }
 
 type GetQueuesResp struct {
@@ -417,24 +417,24 @@ type Queue struct {
 	QueuePassword                                    string `json:"queue_password"`
 	CalleridPrefix                                   string `json:"callerid_prefix"`
 	JoinAnnouncement                                 string `json:"join_announcement"`
-	PriorityWeight                                   string `json:"priority_weight"`
+	PriorityWeight                                   FlexInt `json:"priority_weight"`
 	AgentAnnouncement                                string `json:"agent_announcement"`
-	ReportHoldTimeAgent                              string `json:"report_hold_time_agent"`
-	MemberDelay                                      string `json:"member_delay"`
+	ReportHoldTimeAgent                              FlexBool `json:"report_hold_time_agent"`
+	MemberDelay                                      FlexInt `json:"member_delay"`
 	MusicOnHold                                      string `json:"music_on_hold"`
-	MaximumWaitTime                                  string `json:"maximum_wait_time"`
-	MaximumCallers                                   string `json:"maximum_callers"`
+	MaximumWaitTime                                  FlexInt `json:"maximum_wait_time"`
+	MaximumCallers                                   FlexInt `json:"maximum_callers"`
 	JoinWhenEmpty                                    string `json:"join_when_empty"`
 	LeaveWhenEmpty                                   string `json:"leave_when_empty"`
 	RingStrategy                                     string `json:"ring_strategy"`
-	RingInuse                                        string `json:"ring_inuse"`
-	AgentRingTimeout                                 string `json:"agent_ring_timeout"`
-	RetryTimer                                       string `json:"retry_timer"`
-	WrapupTime                                       string `json:"wrapup_time"`
+	RingInuse                                        FlexBool `json:"ring_inuse"`
+	AgentRingTimeout                                 FlexInt `json:"agent_ring_timeout"`
+	RetryTimer                                       FlexInt `json:"retry_timer"`
+	WrapupTime                                       FlexInt `json:"wrapup_time"`
 	VoiceAnnouncement                                string `json:"voice_announcement"`
-	FrequencyAnnouncement                            string `json:"frequency_announcement"`
-	AnnouncePositionFrequency                        string `json:"announce_position_frecuency"`
-	AnnounceRoundSeconds                             string `json:"announce_round_seconds"`
+	FrequencyAnnouncement                            FlexInt `json:"frequency_announcement"`
+	AnnouncePositionFrequency                        FlexInt `json:"announce_position_frecuency"`
+	AnnounceRoundSeconds                             FlexInt `json:"announce_round_seconds"`
 	IfAnnouncePositionEnabledReportEstimatedHoldTime string `json:"if_announce_position_enabled_report_estimated_hold_time"`
 	ThankyouForYourPatience                          string `json:"thankyou_for_your_patience"`
 	FailOverRoutingTimeout                           BaseRoute `json:"fail_over_routing_timeout"`
@@ -445,26 +445,6 @@ type Queue struct {
 	FailOverRoutingLeaveUnavail                      BaseRoute `json:"fail_over_routing_leave_unavail"`
 }
 
-func (c *RateCenter) UnmarshalJSON(data []byte) error {
-
-	type Alias RateCenter
-	aux := &struct {
-		Available string `json:"available"`
-		*Alias
-	}{
-		Alias: (*Alias)(c),
-	}
-	if err := json.Unmarshal(data, &aux); err != nil {
-		return err
-	}
-
-	if aux.Available == "yes" {
-		c.Available = true
-	}
-
-	return nil
-}
-
 type GetStatesResp struct {
 	BaseResp
 	States []State `json:"states"`
@@ -485,7 +465,7 @@ type Member struct {
 	QueueName string `json:"queue_name"`
 	Name      string `json:"name"`
 	Account   string `json:"account"`
-	Priority  string `json:"priority"`
+	Priority  FlexInt `json:"priority"`
 }
 
 type GetTimeConditionsResp struct {
@@ -498,10 +478,10 @@ type TimeCondition struct {
 	Name           string `json:"name"`
 	RoutingMatch   string `json:"routingmatch"`
 	RoutingNoMatch string `json:"routingnomatch"`
-	StartHour      string `json:"starthour"`
-	StartMinute    string `json:"startminute"`
-	EndHour        string `json:"endhour"`
-	EndMinute      string `json:"endminute"`
+	StartHour      FlexInt `json:"starthour"`
+	StartMinute    FlexInt `json:"startminute"`
+	EndHour        FlexInt `json:"endhour"`
+	EndMinute      FlexInt `json:"endminute"`
 	WeekdayStart   string `json:"weekdaystart"`
 	WeekdayEnd     string `json:"weekdayend"`
 }
@@ -522,9 +502,9 @@ type VoicemailAttachmentFormat StringValueDescription
 
 type DIDOrderResellerConfig struct {
 	Account string `json:"account"`
-	Monthly string `json:"monthly"`
-	Setup   string `json:"setup"`
-	Minute  string `json:"minute"`
+	Monthly Money  `json:"monthly,omitempty"`
+	Setup   Money  `json:"setup,omitempty"`
+	Minute  Money  `json:"minute,omitempty"`
 }
 
 type DIDOrder struct {
@@ -552,13 +532,13 @@ type DID struct {
 	ProvinceDescription string `json:"province_description,omitempty"` //only populated on Canadian API calls
 	State               string `json:"state,omitempty"`                //only populated on US API calls
 	StateDescription    string `json:"state_description,omitempty"`    //only populated on US API calls
-	PerMinuteMonthly    string `json:"perminute_monthly"`
-	PerMinuteMinute     string `json:"perminute_minute"`
-	PerMinuteSetup      string `json:"perminute_setup"`
-	FlatMonthly         string `json:"flat_monthly"`
-	FlatMinute          string `json:"flat_minute"`
-	FlatSetup           string `json:"flat_setup"`
-	SMS                 int `json:"sms,omitempty"`
+	PerMinuteMonthly    Money `json:"perminute_monthly"`
+	PerMinuteMinute     Money `json:"perminute_minute"`
+	PerMinuteSetup      Money `json:"perminute_setup"`
+	FlatMonthly         Money `json:"flat_monthly"`
+	FlatMinute          Money `json:"flat_minute"`
+	FlatSetup           Money `json:"flat_setup"`
+	SMS                 FlexBool `json:"sms,omitempty"`
 }
 
 type DIDSearchType string
@@ -571,10 +551,14 @@ const (
 
 //TODO:Stan this isn't working. It returns "invalid_ratecenter" and I'm pretty sure the ratecenter is correct.
 func (d *DIDsAPI) BackOrderDIDUSA(backOrder *BackOrder) error {
+	return d.BackOrderDIDUSAContext(context.Background(), backOrder)
+}
+
+func (d *DIDsAPI) BackOrderDIDUSAContext(ctx context.Context, backOrder *BackOrder) error {
 	rs := &BaseResp{}
 	rq := backOrder
 
-	if err := d.client.Post("backOrderDIDUSA", rq, rs); err != nil {
+	if err := d.client.PostContext(ctx, "backOrderDIDUSA", rq, rs); err != nil {
 		return err
 	}
 
@@ -583,10 +567,14 @@ func (d *DIDsAPI) BackOrderDIDUSA(backOrder *BackOrder) error {
 
 //TODO:Stan this isn't working. It returns "invalid_ratecenter" and I'm pretty sure the ratecenter is correct.
 func (d *DIDsAPI) BackOrderDIDCan(backOrder *BackOrder) error {
+	return d.BackOrderDIDCanContext(context.Background(), backOrder)
+}
+
+func (d *DIDsAPI) BackOrderDIDCanContext(ctx context.Context, backOrder *BackOrder) error {
 	rs := &BaseResp{}
 	rq := backOrder
 
-	if err := d.client.Post("backOrderDIDCAN", rq, rs); err != nil {
+	if err := d.client.PostContext(ctx, "backOrderDIDCAN", rq, rs); err != nil {
 		return err
 	}
 
@@ -594,52 +582,48 @@ func (d *DIDsAPI) BackOrderDIDCan(backOrder *BackOrder) error {
 }
 
 func (d *DIDsAPI) CancelDID(DID, comment string, portOut, test bool) error {
-	values := url.Values{}
-	values.Add("did", DID)
-
-	if portOut {
-		values.Add("portout", "true")
-	}
+	return d.CancelDIDContext(context.Background(), DID, comment, portOut, test)
+}
 
-	if test {
-		values.Add("test", "true")
-	}
-
-	if comment != "" {
-		values.Add("cancelcomment", comment)
-	}
+func (d *DIDsAPI) CancelDIDContext(ctx context.Context, DID, comment string, portOut, test bool) error {
+	rq := struct {
+		DID           string `url:"did"`
+		PortOut       bool   `url:"portout,omitempty"`
+		Test          bool   `url:"test,omitempty"`
+		CancelComment string `url:"cancelcomment,omitempty"`
+	}{DID, portOut, test, comment}
 
 	rs := &CancelDIDResp{}
 	//TODO:Stan this is called "CancelDID" in the documentation...
-	if err := d.client.Get("cancelDID", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "cancelDID", rq, rs); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (d *DIDsAPI) ConnectDID(DID, account, monthly, setup, minute string, nextBilling time.Time, dontChargeSetup, dontChargeMonthly bool) error {
-	values := url.Values{}
-	values.Add("did", DID)
-	values.Add("account", account)
-	values.Add("monthly", monthly)
-	values.Add("setup", setup)
-	values.Add("minute", minute)
-
-	if !nextBilling.IsZero() {
-		values.Add("next_billing", nextBilling.Format("2006-01-02"))
-	}
+func (d *DIDsAPI) ConnectDID(DID, account string, monthly, setup, minute Money, nextBilling time.Time, dontChargeSetup, dontChargeMonthly bool) error {
+	return d.ConnectDIDContext(context.Background(), DID, account, monthly, setup, minute, nextBilling, dontChargeSetup, dontChargeMonthly)
+}
 
-	if dontChargeSetup {
-		values.Add("dont_charge_setup", "true")
-	}
+func (d *DIDsAPI) ConnectDIDContext(ctx context.Context, DID, account string, monthly, setup, minute Money, nextBilling time.Time, dontChargeSetup, dontChargeMonthly bool) error {
+	rq := struct {
+		DID               string `url:"did"`
+		Account           string `url:"account"`
+		Monthly           Money  `url:"monthly"`
+		Setup             Money  `url:"setup"`
+		Minute            Money  `url:"minute"`
+		NextBilling       string `url:"next_billing,omitempty"`
+		DontChargeSetup   bool   `url:"dont_charge_setup,omitempty"`
+		DontChargeMonthly bool   `url:"dont_charge_monthly,omitempty"`
+	}{DID, account, monthly, setup, minute, "", dontChargeSetup, dontChargeMonthly}
 
-	if dontChargeMonthly {
-		values.Add("dont_charge_monthly", "true")
+	if !nextBilling.IsZero() {
+		rq.NextBilling = nextBilling.Format("2006-01-02")
 	}
 
 	rs := &ConnectDIDResp{}
-	if err := d.client.Get("connectDID", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "connectDID", rq, rs); err != nil {
 		return err
 	}
 
@@ -647,75 +631,134 @@ func (d *DIDsAPI) ConnectDID(DID, account, monthly, setup, minute string, nextBi
 }
 
 func (d *DIDsAPI) DelCallback(callback string) error {
-	return d.client.simpleCall("delCallback", "callback", callback)
+	return d.DelCallbackContext(context.Background(), callback)
+}
+
+func (d *DIDsAPI) DelCallbackContext(ctx context.Context, callback string) error {
+	return d.client.simpleCallContext(ctx, "delCallback", "callback", callback)
 }
 
 func (d *DIDsAPI) DelCallerIDFiltering(filtering string) error {
-	return d.client.simpleCall("delCallerIDFiltering", "filtering", filtering)
+	return d.DelCallerIDFilteringContext(context.Background(), filtering)
+}
+
+func (d *DIDsAPI) DelCallerIDFilteringContext(ctx context.Context, filtering string) error {
+	return d.client.simpleCallContext(ctx, "delCallerIDFiltering", "filtering", filtering)
 }
 
 func (d *DIDsAPI) DelClient(client string) error {
-	return d.client.simpleCall("delClient", "client", client)
+	return d.DelClientContext(context.Background(), client)
+}
+
+func (d *DIDsAPI) DelClientContext(ctx context.Context, client string) error {
+	return d.client.simpleCallContext(ctx, "delClient", "client", client)
 }
 
 func (d *DIDsAPI) DelDISA(disa string) error {
-	return d.client.simpleCall("delDISA", "disa", disa)
+	return d.DelDISAContext(context.Background(), disa)
+}
+
+func (d *DIDsAPI) DelDISAContext(ctx context.Context, disa string) error {
+	return d.client.simpleCallContext(ctx, "delDISA", "disa", disa)
 }
 
 func (d *DIDsAPI) DeleteSMS(id string) error {
-	return d.client.simpleCall("deleteSMS", "id", id)
+	return d.DeleteSMSContext(context.Background(), id)
+}
+
+func (d *DIDsAPI) DeleteSMSContext(ctx context.Context, id string) error {
+	return d.client.simpleCallContext(ctx, "deleteSMS", "id", id)
 }
 
 func (d *DIDsAPI) DelForwarding(forwarding string) error {
-	return d.client.simpleCall("delForwarding", "forwarding", forwarding)
+	return d.DelForwardingContext(context.Background(), forwarding)
+}
+
+func (d *DIDsAPI) DelForwardingContext(ctx context.Context, forwarding string) error {
+	return d.client.simpleCallContext(ctx, "delForwarding", "forwarding", forwarding)
 }
 
 func (d *DIDsAPI) DelIVR(ivr string) error {
-	return d.client.simpleCall("delIVR", "ivr", ivr)
+	return d.DelIVRContext(context.Background(), ivr)
+}
+
+func (d *DIDsAPI) DelIVRContext(ctx context.Context, ivr string) error {
+	return d.client.simpleCallContext(ctx, "delIVR", "ivr", ivr)
 }
 
 func (d *DIDsAPI) DelPhonebook(phonebook string) error {
-	return d.client.simpleCall("delPhonebook", "phonebook", phonebook)
+	return d.DelPhonebookContext(context.Background(), phonebook)
+}
+
+func (d *DIDsAPI) DelPhonebookContext(ctx context.Context, phonebook string) error {
+	return d.client.simpleCallContext(ctx, "delPhonebook", "phonebook", phonebook)
 }
 
 func (d *DIDsAPI) DelQueue(queue string) error {
-	return d.client.simpleCall("delQueue", "queue", queue)
+	return d.DelQueueContext(context.Background(), queue)
+}
+
+func (d *DIDsAPI) DelQueueContext(ctx context.Context, queue string) error {
+	return d.client.simpleCallContext(ctx, "delQueue", "queue", queue)
 }
 
 func (d *DIDsAPI) DelRecording(recording string) error {
-	return d.client.simpleCall("delRecording", "recording", recording)
+	return d.DelRecordingContext(context.Background(), recording)
+}
+
+func (d *DIDsAPI) DelRecordingContext(ctx context.Context, recording string) error {
+	return d.client.simpleCallContext(ctx, "delRecording", "recording", recording)
 }
 
 func (d *DIDsAPI) DelRingGroup(ringGroup string) error {
-	return d.client.simpleCall("delRingGroup", "ringGroup", ringGroup)
+	return d.DelRingGroupContext(context.Background(), ringGroup)
+}
+
+func (d *DIDsAPI) DelRingGroupContext(ctx context.Context, ringGroup string) error {
+	return d.client.simpleCallContext(ctx, "delRingGroup", "ringGroup", ringGroup)
 }
 
 func (d *DIDsAPI) DelSIPURI(SIPURI string) error {
-	return d.client.simpleCall("delSIPURI", "sipuri", SIPURI)
+	return d.DelSIPURIContext(context.Background(), SIPURI)
+}
+
+func (d *DIDsAPI) DelSIPURIContext(ctx context.Context, SIPURI string) error {
+	return d.client.simpleCallContext(ctx, "delSIPURI", "sipuri", SIPURI)
 }
 
 func (d *DIDsAPI) DelStaticMember(member, queue string) error {
-	values := url.Values{}
-	values.Add("member", member)
-	values.Add("queue", queue)
+	return d.DelStaticMemberContext(context.Background(), member, queue)
+}
+
+func (d *DIDsAPI) DelStaticMemberContext(ctx context.Context, member, queue string) error {
+	rq := struct {
+		Member string `url:"member"`
+		Queue  string `url:"queue"`
+	}{member, queue}
 
 	rs := &DelStaticMemberResp{}
-	return d.client.Get("delStaticMember", values, rs)
+	return d.client.GetContext(ctx, "delStaticMember", rq, rs)
 }
 
 func (d *DIDsAPI) DelTimeCondition(timeCondition string) error {
-	return d.client.simpleCall("delTimeCondition", "timecondition", timeCondition)
+	return d.DelTimeConditionContext(context.Background(), timeCondition)
+}
+
+func (d *DIDsAPI) DelTimeConditionContext(ctx context.Context, timeCondition string) error {
+	return d.client.simpleCallContext(ctx, "delTimeCondition", "timecondition", timeCondition)
 }
 
 func (d *DIDsAPI) GetCallbacks(callback string) ([]Callback, error) {
-	values := url.Values{}
+	return d.GetCallbacksContext(context.Background(), callback)
+}
 
-	if callback != "" {
-		values.Add("callback", callback)
-	}
+func (d *DIDsAPI) GetCallbacksContext(ctx context.Context, callback string) ([]Callback, error) {
+	rq := struct {
+		Callback string `url:"callback,omitempty"`
+	}{callback}
 
 	rs := &GetCallbacksResp{}
-	if err := d.client.Get("getCallbacks", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getCallbacks", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -723,14 +766,16 @@ func (d *DIDsAPI) GetCallbacks(callback string) ([]Callback, error) {
 }
 
 func (d *DIDsAPI) GetCallerIDFiltering(filtering string) ([]CallerIDFilter, error) {
-	values := url.Values{}
+	return d.GetCallerIDFilteringContext(context.Background(), filtering)
+}
 
-	if filtering != "" {
-		values.Add("filtering", filtering)
-	}
+func (d *DIDsAPI) GetCallerIDFilteringContext(ctx context.Context, filtering string) ([]CallerIDFilter, error) {
+	rq := struct {
+		Filtering string `url:"filtering,omitempty"`
+	}{filtering}
 
 	rs := &GetCallerIDFilteringResp{}
-	if err := d.client.Get("getCallerIDFiltering", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getCallerIDFiltering", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -738,14 +783,16 @@ func (d *DIDsAPI) GetCallerIDFiltering(filtering string) ([]CallerIDFilter, erro
 }
 
 func (d *DIDsAPI) GetCarriers(carrier string) ([]Carrier, error) {
-	values := url.Values{}
+	return d.GetCarriersContext(context.Background(), carrier)
+}
 
-	if carrier != "" {
-		values.Add("carrier", carrier)
-	}
+func (d *DIDsAPI) GetCarriersContext(ctx context.Context, carrier string) ([]Carrier, error) {
+	rq := struct {
+		Carrier string `url:"carrier,omitempty"`
+	}{carrier}
 
 	rs := &GetCarriersResp{}
-	if err := d.client.Get("getCarriers", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getCarriers", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -753,15 +800,17 @@ func (d *DIDsAPI) GetCarriers(carrier string) ([]Carrier, error) {
 }
 
 func (d *DIDsAPI) GetDIDCountries(countryId, typ3 string) ([]DIDCountries, error) {
-	values := url.Values{}
-	values.Add("type", typ3)
+	return d.GetDIDCountriesContext(context.Background(), countryId, typ3)
+}
 
-	if countryId != "" {
-		values.Add("country_id", countryId)
-	}
+func (d *DIDsAPI) GetDIDCountriesContext(ctx context.Context, countryId, typ3 string) ([]DIDCountries, error) {
+	rq := struct {
+		Type      string `url:"type"`
+		CountryID string `url:"country_id,omitempty"`
+	}{typ3, countryId}
 
 	rs := &GetDIDCountriesResp{}
-	if err := d.client.Get("getDIDCountries", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDCountries", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -769,15 +818,17 @@ func (d *DIDsAPI) GetDIDCountries(countryId, typ3 string) ([]DIDCountries, error
 }
 
 func (d *DIDsAPI) GetDIDsCan(province, rateCenter string) ([]DID, error) {
-	values := url.Values{}
-	values.Add("province", province)
+	return d.GetDIDsCanContext(context.Background(), province, rateCenter)
+}
 
-	if rateCenter != "" {
-		values.Add("ratecenter", rateCenter)
-	}
+func (d *DIDsAPI) GetDIDsCanContext(ctx context.Context, province, rateCenter string) ([]DID, error) {
+	rq := struct {
+		Province   string `url:"province"`
+		RateCenter string `url:"ratecenter,omitempty"`
+	}{province, rateCenter}
 
 	rs := &GetDIDsCanResp{}
-	if err := d.client.Get("getDIDsCAN", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsCAN", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -785,18 +836,17 @@ func (d *DIDsAPI) GetDIDsCan(province, rateCenter string) ([]DID, error) {
 }
 
 func (d *DIDsAPI) GetDIDsInfo(client, DID string) ([]DIDInfo, error) {
-	values := url.Values{}
-
-	if client != "" {
-		values.Add("client", client)
-	}
+	return d.GetDIDsInfoContext(context.Background(), client, DID)
+}
 
-	if DID != "" {
-		values.Add("did", DID)
-	}
+func (d *DIDsAPI) GetDIDsInfoContext(ctx context.Context, client, DID string) ([]DIDInfo, error) {
+	rq := struct {
+		Client string `url:"client,omitempty"`
+		DID    string `url:"did,omitempty"`
+	}{client, DID}
 
 	rs := &GetDIDsInfoResp{}
-	if err := d.client.Get("getDIDsInfo", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsInfo", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -804,11 +854,16 @@ func (d *DIDsAPI) GetDIDsInfo(client, DID string) ([]DIDInfo, error) {
 }
 
 func (d *DIDsAPI) GetDIDsInternationalGeographic(countryId string) ([]InternationalLocations, error) {
-	values := url.Values{}
-	values.Add("country_id", countryId)
+	return d.GetDIDsInternationalGeographicContext(context.Background(), countryId)
+}
+
+func (d *DIDsAPI) GetDIDsInternationalGeographicContext(ctx context.Context, countryId string) ([]InternationalLocations, error) {
+	rq := struct {
+		CountryID string `url:"country_id"`
+	}{countryId}
 
 	rs := &GetDIDsInternationalResp{}
-	if err := d.client.Get("getDIDsInternationalGeographic", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsInternationalGeographic", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -816,11 +871,16 @@ func (d *DIDsAPI) GetDIDsInternationalGeographic(countryId string) ([]Internatio
 }
 
 func (d *DIDsAPI) GetDIDsInternationalNational(countryId string) ([]InternationalLocations, error) {
-	values := url.Values{}
-	values.Add("country_id", countryId)
+	return d.GetDIDsInternationalNationalContext(context.Background(), countryId)
+}
+
+func (d *DIDsAPI) GetDIDsInternationalNationalContext(ctx context.Context, countryId string) ([]InternationalLocations, error) {
+	rq := struct {
+		CountryID string `url:"country_id"`
+	}{countryId}
 
 	rs := &GetDIDsInternationalResp{}
-	if err := d.client.Get("getDIDsInternationalNational", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsInternationalNational", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -828,11 +888,16 @@ func (d *DIDsAPI) GetDIDsInternationalNational(countryId string) ([]Internationa
 }
 
 func (d *DIDsAPI) GetDIDsInternationalTollFree(countryId string) ([]InternationalLocations, error) {
-	values := url.Values{}
-	values.Add("country_id", countryId)
+	return d.GetDIDsInternationalTollFreeContext(context.Background(), countryId)
+}
+
+func (d *DIDsAPI) GetDIDsInternationalTollFreeContext(ctx context.Context, countryId string) ([]InternationalLocations, error) {
+	rq := struct {
+		CountryID string `url:"country_id"`
+	}{countryId}
 
 	rs := &GetDIDsInternationalResp{}
-	if err := d.client.Get("getDIDsInternationalTollFree", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsInternationalTollFree", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -840,15 +905,17 @@ func (d *DIDsAPI) GetDIDsInternationalTollFree(countryId string) ([]Internationa
 }
 
 func (d *DIDsAPI) GetDIDsUSA(state, rateCenter string) ([]DID, error) {
-	values := url.Values{}
-	values.Add("state", state)
+	return d.GetDIDsUSAContext(context.Background(), state, rateCenter)
+}
 
-	if rateCenter != "" {
-		values.Add("ratecenter", rateCenter)
-	}
+func (d *DIDsAPI) GetDIDsUSAContext(ctx context.Context, state, rateCenter string) ([]DID, error) {
+	rq := struct {
+		State      string `url:"state"`
+		RateCenter string `url:"ratecenter,omitempty"`
+	}{state, rateCenter}
 
 	rs := &GetDIDsUSAResp{}
-	if err := d.client.Get("getDIDsUSA", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDIDsUSA", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -856,14 +923,16 @@ func (d *DIDsAPI) GetDIDsUSA(state, rateCenter string) ([]DID, error) {
 }
 
 func (d *DIDsAPI) GetDISAs(DISA string) ([]DISA, error) {
-	values := url.Values{}
+	return d.GetDISAsContext(context.Background(), DISA)
+}
 
-	if DISA != "" {
-		values.Add("disa", DISA)
-	}
+func (d *DIDsAPI) GetDISAsContext(ctx context.Context, DISA string) ([]DISA, error) {
+	rq := struct {
+		DISA string `url:"disa,omitempty"`
+	}{DISA}
 
 	rs := &GetDISAsResp{}
-	if err := d.client.Get("getDISAs", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getDISAs", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -871,14 +940,16 @@ func (d *DIDsAPI) GetDISAs(DISA string) ([]DISA, error) {
 }
 
 func (d *DIDsAPI) GetForwardings(forwarding string) ([]Forwarding, error) {
-	values := url.Values{}
+	return d.GetForwardingsContext(context.Background(), forwarding)
+}
 
-	if forwarding != "" {
-		values.Add("forwarding", forwarding)
-	}
+func (d *DIDsAPI) GetForwardingsContext(ctx context.Context, forwarding string) ([]Forwarding, error) {
+	rq := struct {
+		Forwarding string `url:"forwarding,omitempty"`
+	}{forwarding}
 
 	rs := &GetForwardingsResp{}
-	if err := d.client.Get("getForwardings", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getForwardings", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -886,14 +957,16 @@ func (d *DIDsAPI) GetForwardings(forwarding string) ([]Forwarding, error) {
 }
 
 func (d *DIDsAPI) GetInternationalTypes(typ3 string) ([]InternationalTypes, error) {
-	values := url.Values{}
+	return d.GetInternationalTypesContext(context.Background(), typ3)
+}
 
-	if typ3 != "" {
-		values.Add("type", typ3)
-	}
+func (d *DIDsAPI) GetInternationalTypesContext(ctx context.Context, typ3 string) ([]InternationalTypes, error) {
+	rq := struct {
+		Type string `url:"type,omitempty"`
+	}{typ3}
 
 	rs := &GetInternationalTypesResp{}
-	if err := d.client.Get("getInternationalTypes", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getInternationalTypes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -901,14 +974,16 @@ func (d *DIDsAPI) GetInternationalTypes(typ3 string) ([]InternationalTypes, erro
 }
 
 func (d *DIDsAPI) GetIVRs(IVR string) ([]IVR, error) {
-	values := url.Values{}
+	return d.GetIVRsContext(context.Background(), IVR)
+}
 
-	if IVR != "" {
-		values.Add("ivr", IVR)
-	}
+func (d *DIDsAPI) GetIVRsContext(ctx context.Context, IVR string) ([]IVR, error) {
+	rq := struct {
+		IVR string `url:"ivr,omitempty"`
+	}{IVR}
 
 	rs := &GetIVRsResp{}
-	if err := d.client.Get("getIVRs", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getIVRs", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -916,14 +991,16 @@ func (d *DIDsAPI) GetIVRs(IVR string) ([]IVR, error) {
 }
 
 func (d *DIDsAPI) GetJoinWhenEmptyTypes(typ3 string) ([]JoinWhenEmptyType, error) {
-	values := url.Values{}
+	return d.GetJoinWhenEmptyTypesContext(context.Background(), typ3)
+}
 
-	if typ3 != "" {
-		values.Add("type", typ3)
-	}
+func (d *DIDsAPI) GetJoinWhenEmptyTypesContext(ctx context.Context, typ3 string) ([]JoinWhenEmptyType, error) {
+	rq := struct {
+		Type string `url:"type,omitempty"`
+	}{typ3}
 
 	rs := &GetJoinWhenEmptyTypesResp{}
-	if err := d.client.Get("getJoinWhenEmptyTypes", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getJoinWhenEmptyTypes", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -931,18 +1008,17 @@ func (d *DIDsAPI) GetJoinWhenEmptyTypes(typ3 string) ([]JoinWhenEmptyType, error
 }
 
 func (d *DIDsAPI) GetPhonebook(phonebook, name string) ([]Phonebook, error) {
-	values := url.Values{}
-
-	if phonebook != "" {
-		values.Add("phonebook", phonebook)
-	}
+	return d.GetPhonebookContext(context.Background(), phonebook, name)
+}
 
-	if name != "" {
-		values.Add("name", name)
-	}
+func (d *DIDsAPI) GetPhonebookContext(ctx context.Context, phonebook, name string) ([]Phonebook, error) {
+	rq := struct {
+		Phonebook string `url:"phonebook,omitempty"`
+		Name      string `url:"name,omitempty"`
+	}{phonebook, name}
 
 	rs := &GetPhonebookResp{}
-	if err := d.client.Get("getPhonebook", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getPhonebook", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -950,24 +1026,31 @@ func (d *DIDsAPI) GetPhonebook(phonebook, name string) ([]Phonebook, error) {
 }
 
 func (d *DIDsAPI) GetPortability(DID string) (bool, []Plan, error) {
-	values := url.Values{}
-	values.Add("did", DID)
+	return d.GetPortabilityContext(context.Background(), DID)
+}
+
+func (d *DIDsAPI) GetPortabilityContext(ctx context.Context, DID string) (bool, []Plan, error) {
+	rq := struct {
+		DID string `url:"did"`
+	}{DID}
 
 	rs := &GetPortabilityResp{}
-	if err := d.client.Get("getPortability", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getPortability", rq, rs); err != nil {
 		return false, nil, err
 	}
 
-	portable := rs.Portable == "yes"
+	portable := rs.Portable.Bool()
 
 	return portable, rs.Plans, nil
 }
 
 func (d *DIDsAPI) GetProvinces() ([]Province, error) {
-	values := url.Values{}
+	return d.GetProvincesContext(context.Background())
+}
 
+func (d *DIDsAPI) GetProvincesContext(ctx context.Context) ([]Province, error) {
 	rs := &GetProvincesResp{}
-	if err := d.client.Get("getProvinces", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getProvinces", nil, rs); err != nil {
 		return nil, err
 	}
 
@@ -975,14 +1058,16 @@ func (d *DIDsAPI) GetProvinces() ([]Province, error) {
 }
 
 func (d *DIDsAPI) GetQueues(queue string) ([]Queue, error) {
-	values := url.Values{}
+	return d.GetQueuesContext(context.Background(), queue)
+}
 
-	if queue != "" {
-		values.Add("queue", queue)
-	}
+func (d *DIDsAPI) GetQueuesContext(ctx context.Context, queue string) ([]Queue, error) {
+	rq := struct {
+		Queue string `url:"queue,omitempty"`
+	}{queue}
 
 	rs := &GetQueuesResp{}
-	if err := d.client.Get("getQueues", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getQueues", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -990,11 +1075,16 @@ func (d *DIDsAPI) GetQueues(queue string) ([]Queue, error) {
 }
 
 func (d *DIDsAPI) GetRateCentersCan(province string) ([]RateCenter, error) {
-	values := url.Values{}
-	values.Add("province", province)
+	return d.GetRateCentersCanContext(context.Background(), province)
+}
+
+func (d *DIDsAPI) GetRateCentersCanContext(ctx context.Context, province string) ([]RateCenter, error) {
+	rq := struct {
+		Province string `url:"province"`
+	}{province}
 
 	rs := &GetRateCentersResp{}
-	if err := d.client.Get("getRateCentersCAN", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getRateCentersCAN", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1002,11 +1092,16 @@ func (d *DIDsAPI) GetRateCentersCan(province string) ([]RateCenter, error) {
 }
 
 func (d *DIDsAPI) GetRateCentersUSA(state string) ([]RateCenter, error) {
-	values := url.Values{}
-	values.Add("state", state)
+	return d.GetRateCentersUSAContext(context.Background(), state)
+}
+
+func (d *DIDsAPI) GetRateCentersUSAContext(ctx context.Context, state string) ([]RateCenter, error) {
+	rq := struct {
+		State string `url:"state"`
+	}{state}
 
 	rs := &GetRateCentersResp{}
-	if err := d.client.Get("getRateCentersUSA", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getRateCentersUSA", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1038,8 +1133,12 @@ func (d *DIDsAPI) GetSMS() error {
 }
 
 func (d *DIDsAPI) GetStates() ([]State, error) {
+	return d.GetStatesContext(context.Background())
+}
+
+func (d *DIDsAPI) GetStatesContext(ctx context.Context) ([]State, error) {
 	rs := &GetStatesResp{}
-	if err := d.client.Get("getStates", url.Values{}, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getStates", nil, rs); err != nil {
 		return nil, err
 	}
 
@@ -1047,15 +1146,17 @@ func (d *DIDsAPI) GetStates() ([]State, error) {
 }
 
 func (d *DIDsAPI) GetStaticMembers(queue, member string) ([]Member, error) {
-	values := url.Values{}
-	values.Add("queue", queue)
+	return d.GetStaticMembersContext(context.Background(), queue, member)
+}
 
-	if member != "" {
-		values.Add("member", member)
-	}
+func (d *DIDsAPI) GetStaticMembersContext(ctx context.Context, queue, member string) ([]Member, error) {
+	rq := struct {
+		Queue  string `url:"queue"`
+		Member string `url:"member,omitempty"`
+	}{queue, member}
 
 	rs := &GetStaticMembersResp{}
-	if err := d.client.Get("getStaticMembers", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getStaticMembers", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1063,14 +1164,16 @@ func (d *DIDsAPI) GetStaticMembers(queue, member string) ([]Member, error) {
 }
 
 func (d *DIDsAPI) GetTimeConditions(timeCondition string) ([]TimeCondition, error) {
-	values := url.Values{}
+	return d.GetTimeConditionsContext(context.Background(), timeCondition)
+}
 
-	if timeCondition != "" {
-		values.Add("timecondition", timeCondition)
-	}
+func (d *DIDsAPI) GetTimeConditionsContext(ctx context.Context, timeCondition string) ([]TimeCondition, error) {
+	rq := struct {
+		TimeCondition string `url:"timecondition,omitempty"`
+	}{timeCondition}
 
 	rs := &GetTimeConditionsResp{}
-	if err := d.client.Get("getTimeConditions", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getTimeConditions", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1078,14 +1181,16 @@ func (d *DIDsAPI) GetTimeConditions(timeCondition string) ([]TimeCondition, erro
 }
 
 func (d *DIDsAPI) GetVoicemailSetups(voicemailSetup string) ([]VoicemailSetup, error) {
-	values := url.Values{}
+	return d.GetVoicemailSetupsContext(context.Background(), voicemailSetup)
+}
 
-	if voicemailSetup != "" {
-		values.Add("voicemailsetup", voicemailSetup)
-	}
+func (d *DIDsAPI) GetVoicemailSetupsContext(ctx context.Context, voicemailSetup string) ([]VoicemailSetup, error) {
+	rq := struct {
+		VoicemailSetup string `url:"voicemailsetup,omitempty"`
+	}{voicemailSetup}
 
 	rs := &GetVoicemailSetups{}
-	if err := d.client.Get("getVoicemailSetups", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getVoicemailSetups", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1093,14 +1198,16 @@ func (d *DIDsAPI) GetVoicemailSetups(voicemailSetup string) ([]VoicemailSetup, e
 }
 
 func (d *DIDsAPI) GetVoicemailAttachmentFormats(emailAttachmentFormat string) ([]VoicemailAttachmentFormat, error) {
-	values := url.Values{}
+	return d.GetVoicemailAttachmentFormatsContext(context.Background(), emailAttachmentFormat)
+}
 
-	if emailAttachmentFormat != "" {
-		values.Add("email_attachment_format", emailAttachmentFormat)
-	}
+func (d *DIDsAPI) GetVoicemailAttachmentFormatsContext(ctx context.Context, emailAttachmentFormat string) ([]VoicemailAttachmentFormat, error) {
+	rq := struct {
+		EmailAttachmentFormat string `url:"email_attachment_format,omitempty"`
+	}{emailAttachmentFormat}
 
 	rs := &GetVoicemailAttachmentFormats{}
-	if err := d.client.Get("getVoicemailAttachmentFormats", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "getVoicemailAttachmentFormats", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -1108,10 +1215,14 @@ func (d *DIDsAPI) GetVoicemailAttachmentFormats(emailAttachmentFormat string) ([
 }
 
 func (d *DIDsAPI) OrderDID(didOrder *DIDOrder) error {
+	return d.OrderDIDContext(context.Background(), didOrder)
+}
+
+func (d *DIDsAPI) OrderDIDContext(ctx context.Context, didOrder *DIDOrder) error {
 	rs := &BaseResp{}
 	rq := didOrder
 
-	if err := d.client.Post("orderDID", rq, rs); err != nil {
+	if err := d.client.PostContext(ctx, "orderDID", rq, rs); err != nil {
 		return err
 	}
 
@@ -1119,10 +1230,14 @@ func (d *DIDsAPI) OrderDID(didOrder *DIDOrder) error {
 }
 
 func (d *DIDsAPI) OrderDIDInternationalGeographic(didOrder *DIDOrderInternationalGeographic) error {
+	return d.OrderDIDInternationalGeographicContext(context.Background(), didOrder)
+}
+
+func (d *DIDsAPI) OrderDIDInternationalGeographicContext(ctx context.Context, didOrder *DIDOrderInternationalGeographic) error {
 	rs := &BaseResp{}
 	rq := didOrder
 
-	if err := d.client.Post("orderDIDInternationalGeographic", rq, rs); err != nil {
+	if err := d.client.PostContext(ctx, "orderDIDInternationalGeographic", rq, rs); err != nil {
 		return err
 	}
 
@@ -1150,16 +1265,18 @@ func (d *DIDsAPI) OrderVanity() error {
 }
 
 func (d *DIDsAPI) SearchDIDsCan(province string, typ3 DIDSearchType, query string) ([]DID, error) {
-	values := url.Values{}
-	values.Add("type", string(typ3))
-	values.Add("query", query)
+	return d.SearchDIDsCanContext(context.Background(), province, typ3, query)
+}
 
-	if province != "" {
-		values.Add("province", province)
-	}
+func (d *DIDsAPI) SearchDIDsCanContext(ctx context.Context, province string, typ3 DIDSearchType, query string) ([]DID, error) {
+	rq := struct {
+		Type     DIDSearchType `url:"type"`
+		Query    string        `url:"query"`
+		Province string        `url:"province,omitempty"`
+	}{typ3, query, province}
 
 	rs := &SearchDIDsCanResp{}
-	if err := d.client.Get("searchDIDsCAN", values, rs); err != nil {
+	if err := d.client.GetContext(ctx, "searchDIDsCAN", rq, rs); err != nil {
 		return nil, err
 	}
 
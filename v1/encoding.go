@@ -0,0 +1,386 @@
+package v1
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Separator voip.ms uses for list parameters, i.e. allowed_codecs=ulaw;g729.
+const ListSeparator = ";"
+
+//Turns a request struct into the parameters sent to voip.ms. Fields are named by their url tag, falling back to their
+//json tag and then their lower cased field name. Tag options are:
+//
+//	omitempty  skip the zero value: "", 0, false, an empty slice or a zero struct.
+//	int        send a bool as 1 or 0 instead of true or false.
+//	-          never send the field.
+//
+//Pointers are optional fields: nil is never sent, anything else is sent even when it's the zero value, so a *bool set
+//to false sends an explicit false. Slices are joined with ListSeparator. encoding.TextMarshalers are sent as their
+//text. Other structs, embedded or not, are flattened into the parameters and it's an error for two fields to end up
+//with the same name. A url.Values is returned as is.
+func EncodeValues(v interface{}) (url.Values, error) {
+	if values, ok := v.(url.Values); ok {
+		return values, nil
+	}
+
+	values := url.Values{}
+	err := encodeFields(v, func(name, value string) error {
+		values.Add(name, value)
+		return nil
+	})
+	return values, err
+}
+
+//Fills in the request struct v points to from values, the reverse of EncodeValues. Parameters without a field are
+//ignored. Bools accept true, 1 and yes.
+func DecodeValues(values url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("voipms: DecodeValues needs a pointer to a struct, not %T", v)
+	}
+
+	fields, err := cachedFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		if _, ok := values[f.name]; !ok {
+			continue
+		}
+		if err := decodeValue(fieldByIndex(rv.Elem(), f.index, true), values.Get(f.name)); err != nil {
+			return fmt.Errorf("voipms: decoding %s: %v", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+//Writes the fields of v, in struct order, through write.
+func encodeFields(v interface{}, write func(name, value string) error) error {
+	if v == nil {
+		return nil
+	}
+
+	if values, ok := v.(url.Values); ok {
+		for name, vs := range values {
+			for _, value := range vs {
+				if err := write(name, value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("voipms: can't encode %T as parameters", v)
+	}
+
+	fields, err := cachedFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		fv := fieldByIndex(rv, f.index, false)
+		if !fv.IsValid() { //Inside a nil embedded pointer.
+			continue
+		}
+
+		value, ok, err := encodeValue(fv, f)
+		if err != nil {
+			return fmt.Errorf("voipms: encoding %s: %v", f.name, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := write(f.name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//A parameter a struct field encodes to.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	asInt     bool
+}
+
+var fieldCache sync.Map //reflect.Type to []field.
+
+func cachedFields(t reflect.Type) ([]field, error) {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]field), nil
+	}
+
+	fields := []field{}
+	seen := map[string]string{}
+	if err := collectFields(t, nil, "", &fields, seen); err != nil {
+		return nil, err
+	}
+
+	fieldCache.Store(t, fields)
+	return fields, nil
+}
+
+func collectFields(t reflect.Type, index []int, path string, fields *[]field, seen map[string]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr && sf.Anonymous {
+			ft = ft.Elem()
+		}
+
+		if sf.PkgPath != "" && !(sf.Anonymous && ft.Kind() == reflect.Struct) { //Unexported, other than embedded structs.
+			continue
+		}
+
+		name, options := fieldTag(sf)
+		if name == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+		fieldPath := path + sf.Name
+
+		if ft.Kind() == reflect.Struct && !isTextMarshaler(ft) && !hasTag(sf) {
+			if err := collectFields(ft, fieldIndex, fieldPath+".", fields, seen); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("voipms: %s and %s both encode to %q", other, fieldPath, name)
+		}
+		seen[name] = fieldPath
+
+		*fields = append(*fields, field{
+			name:      name,
+			index:     fieldIndex,
+			omitEmpty: options["omitempty"],
+			asInt:     options["int"],
+		})
+	}
+
+	return nil
+}
+
+//Returns the name and options from the url tag, or the json tag when there isn't one.
+func fieldTag(sf reflect.StructField) (string, map[string]bool) {
+	tag, ok := sf.Tag.Lookup("url")
+	if !ok {
+		tag = sf.Tag.Get("json")
+	}
+
+	parts := strings.Split(tag, ",")
+	options := map[string]bool{}
+	for _, o := range parts[1:] {
+		options[o] = true
+	}
+	return parts[0], options
+}
+
+//Nested structs with a name of their own in a tag are sent as one parameter, which only works for TextMarshalers, so
+//they're left to fail in encodeValue rather than flattened.
+func hasTag(sf reflect.StructField) bool {
+	if sf.Anonymous {
+		return false
+	}
+	name, _ := fieldTag(sf)
+	return name != ""
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func isTextMarshaler(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)
+}
+
+//Walks index from v, allocating nil embedded pointers when alloc is set. Returns the zero Value when it runs into a
+//nil pointer it can't allocate.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+//Returns the text for v and false when it shouldn't be sent at all.
+func encodeValue(v reflect.Value, f field) (string, bool, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		return encodeScalar(v.Elem(), f.asInt)
+	}
+
+	if f.omitEmpty && isEmpty(v) {
+		return "", false, nil
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && !isTextMarshaler(v.Type()) {
+		items := make([]string, v.Len())
+		for i := range items {
+			item, _, err := encodeScalar(v.Index(i), f.asInt)
+			if err != nil {
+				return "", false, err
+			}
+			items[i] = item
+		}
+		return strings.Join(items, ListSeparator), true, nil
+	}
+
+	return encodeScalar(v, f.asInt)
+}
+
+func encodeScalar(v reflect.Value, asInt bool) (string, bool, error) {
+	if v.Type().Implements(textMarshalerType) {
+		return marshalText(v.Interface().(encoding.TextMarshaler))
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		return marshalText(v.Addr().Interface().(encoding.TextMarshaler))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		switch {
+		case asInt && v.Bool():
+			return "1", true, nil
+		case asInt:
+			return "0", true, nil
+		}
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true, nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "", false, nil
+		}
+		return encodeScalar(v.Elem(), asInt)
+	}
+
+	return "", false, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func marshalText(tm encoding.TextMarshaler) (string, bool, error) {
+	text, err := tm.MarshalText()
+	if err != nil {
+		return "", false, err
+	}
+	return string(text), true, nil
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func decodeValue(v reflect.Value, text string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(v.Elem(), text)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "true", "1", "yes":
+			v.SetBool(true)
+		case "false", "0", "no", "":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("invalid bool %q", text)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if text == "" {
+			v.SetInt(0)
+			return nil
+		}
+		i, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if text == "" {
+			v.SetUint(0)
+			return nil
+		}
+		i, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		if text == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		if text != "" {
+			items = strings.Split(text, ListSeparator)
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeValue(s.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
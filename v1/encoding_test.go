@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type encodeInner struct {
+	Note string `url:"note,omitempty"`
+}
+
+type encodeReq struct {
+	Name      string    `url:"name"`
+	Optional  string    `url:"optional,omitempty"`
+	Count     int       `url:"count,omitempty"`
+	Rate      float64   `url:"rate"`
+	Enabled   bool      `url:"enabled,int"`
+	Flag      bool      `url:"flag,omitempty"`
+	Codecs    []string  `url:"codecs,omitempty"`
+	Members   []int     `url:"members"`
+	Explicit  *bool     `url:"explicit"`
+	Missing   *string   `url:"missing"`
+	Routing   BaseRoute `url:"routing"`
+	Failover  BaseRoute `url:"failover,omitempty"`
+	Ignored   string    `url:"-"`
+	JSONNamed string    `json:"json_named,omitempty"`
+	Plain     string
+	encodeInner
+}
+
+func TestEncodeValues(t *testing.T) {
+
+	//setup
+	f := false
+	rq := &encodeReq{
+		Name:     "office",
+		Rate:     0.0125,
+		Codecs:   []string{"ulaw", "g729"},
+		Members:  []int{},
+		Explicit: &f,
+		Routing:  NewAccountRoute("100000_office"),
+		Ignored:  "x",
+		Plain:    "p",
+		encodeInner: encodeInner{
+			Note: "n",
+		},
+	}
+
+	//execute
+	values, err := EncodeValues(rq)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"name":     {"office"},
+		"rate":     {"0.0125"},
+		"enabled":  {"0"},
+		"codecs":   {"ulaw;g729"},
+		"members":  {""},
+		"explicit": {"false"},
+		"routing":  {"account:100000_office"},
+		"plain":    {"p"},
+		"note":     {"n"},
+	}, values)
+}
+
+func TestEncodeValues_Collision(t *testing.T) {
+
+	//setup
+	type nested struct {
+		Account string `url:"account"`
+	}
+	rq := struct {
+		Account string `url:"account"`
+		nested
+	}{}
+
+	//execute
+	_, err := EncodeValues(rq)
+
+	//verify
+	require.EqualError(t, err, `voipms: Account and nested.Account both encode to "account"`)
+}
+
+func TestEncodeValues_Embedded(t *testing.T) {
+
+	//setup
+	rq := &DIDOrder{
+		Did: "5555551234",
+		Order: Order{
+			Routing:     NewAccountRoute("100000_office"),
+			POP:         "1",
+			Dialtime:    60,
+			CNAM:        true,
+			BillingType: 1,
+		},
+	}
+
+	//execute
+	values, err := EncodeValues(rq)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "5555551234", values.Get("did"))
+	require.Equal(t, "account:100000_office", values.Get("routing"))
+	require.Equal(t, "60", values.Get("dialtime"))
+	require.NotContains(t, values, "failover_busy")
+	require.NotContains(t, values, "test")
+	require.Contains(t, values, "account")
+}
+
+func TestEncodeValues_Unsupported(t *testing.T) {
+
+	//setup
+	rq := struct {
+		Extra map[string]string `url:"extra"`
+	}{map[string]string{"a": "b"}}
+
+	//execute
+	_, err := EncodeValues(rq)
+
+	//verify
+	require.EqualError(t, err, "voipms: encoding extra: unsupported type map[string]string")
+}
+
+func TestDecodeValues(t *testing.T) {
+
+	//setup
+	values := url.Values{
+		"name":     {"office"},
+		"count":    {"3"},
+		"rate":     {"0.0125"},
+		"enabled":  {"1"},
+		"codecs":   {"ulaw;g729"},
+		"explicit": {"false"},
+		"routing":  {"fwd:5555551234"},
+		"note":     {"n"},
+		"unknown":  {"x"},
+	}
+	rq := &encodeReq{}
+
+	//execute
+	err := DecodeValues(values, rq)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "office", rq.Name)
+	require.Equal(t, 3, rq.Count)
+	require.Equal(t, 0.0125, rq.Rate)
+	require.True(t, rq.Enabled)
+	require.Equal(t, []string{"ulaw", "g729"}, rq.Codecs)
+	require.NotNil(t, rq.Explicit)
+	require.False(t, *rq.Explicit)
+	require.Nil(t, rq.Missing)
+	require.Equal(t, NewFwdRoute("5555551234"), rq.Routing)
+	require.Equal(t, "n", rq.Note)
+
+	encoded, err := EncodeValues(rq)
+	require.NoError(t, err)
+	require.Equal(t, "ulaw;g729", encoded.Get("codecs"))
+}
+
+func TestVOIPClient_WriteStruct(t *testing.T) {
+
+	//setup
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	c := NewVOIPClient("", "", "")
+
+	//execute
+	err := c.WriteStruct(writer, &SignupClientReq{Client: Client{FirstName: "Jane"}, Activate: false})
+	writer.Close()
+
+	//verify
+	require.NoError(t, err)
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Jane"}, form.Value["firstname"])
+	require.Equal(t, []string{"0"}, form.Value["activate"])
+	require.NotContains(t, form.Value, "company")
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//Categories an *APIError can be matched against with errors.Is.
+var (
+	ErrAuth                = errors.New("voipms: authentication failed")
+	ErrValidation          = errors.New("voipms: invalid request")
+	ErrNotFound            = errors.New("voipms: not found")
+	ErrInsufficientBalance = errors.New("voipms: insufficient balance")
+	ErrRateLimited         = errors.New("voipms: rate limited")
+	ErrUnavailable         = errors.New("voipms: service unavailable")
+)
+
+//Returned when voip.ms answers with a status other than "success" or the HTTP request itself isn't a 200.
+type APIError struct {
+	Method      string //voip.ms method name, i.e. getBalance.
+	Status      string //Raw voip.ms status, i.e. invalid_credentials. Empty when the HTTP response failed.
+	HTTPStatus  int
+	Description string
+}
+
+func newAPIError(method, status string, httpStatus int) *APIError {
+	e := &APIError{
+		Method:     method,
+		Status:     status,
+		HTTPStatus: httpStatus,
+	}
+
+	if status == "" {
+		e.Description = http.StatusText(httpStatus)
+	} else if d, ok := statusDescriptions[status]; ok {
+		e.Description = d
+	} else {
+		e.Description = strings.Replace(status, "_", " ", -1)
+	}
+
+	return e
+}
+
+//The raw status is returned on its own so callers that used to compare error strings keep working.
+func (e *APIError) Error() string {
+	if e.Status != "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%d %s", e.HTTPStatus, http.StatusText(e.HTTPStatus))
+}
+
+//Unwrap returns the category sentinel so errors.Is(err, ErrAuth) and friends work. nil when the status isn't categorized.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.HTTPStatus == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.HTTPStatus >= 500:
+		return ErrUnavailable
+	case e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden:
+		return ErrAuth
+	}
+
+	s := e.Status
+	switch {
+	case s == "":
+		return nil
+	case authStatuses[s]:
+		return ErrAuth
+	case rateLimitStatuses[s]:
+		return ErrRateLimited
+	case balanceStatuses[s]:
+		return ErrInsufficientBalance
+	case strings.HasPrefix(s, "no_") || strings.HasSuffix(s, "_not_found"):
+		return ErrNotFound
+	case strings.HasPrefix(s, "missing_") || strings.HasPrefix(s, "invalid_"):
+		return ErrValidation
+	}
+
+	return nil
+}
+
+var authStatuses = map[string]bool{
+	"invalid_credentials": true,
+	"missing_credentials": true,
+	"ip_not_enabled":      true,
+	"api_not_enabled":     true,
+	"invalid_ip":          true,
+}
+
+var rateLimitStatuses = map[string]bool{
+	"limit_reached":      true,
+	"too_many_requests":  true,
+	"api_limit_exceeded": true,
+}
+
+var balanceStatuses = map[string]bool{
+	"non_sufficient_funds": true,
+	"insufficient_funds":   true,
+	"no_balance":           true,
+}
+
+//Descriptions for the statuses voip.ms documents. Anything else falls back to the status with spaces.
+var statusDescriptions = map[string]string{
+	"invalid_credentials":  "Username or Password is incorrect",
+	"missing_credentials":  "Username or Password was not provided",
+	"ip_not_enabled":       "This IP is not enabled for API use",
+	"api_not_enabled":      "API has not been enabled or has been disabled",
+	"invalid_ip":           "This IP is not enabled for API use",
+	"invalid_method":       "This is not a valid method",
+	"missing_method":       "Method must be provided",
+	"limit_reached":        "You have reached the maximum number of requests allowed",
+	"too_many_requests":    "You have reached the maximum number of requests allowed",
+	"non_sufficient_funds": "Your account does not have sufficient funds to proceed",
+	"insufficient_funds":   "Your account does not have sufficient funds to proceed",
+	"invalid_ratecenter":   "This is not a valid Ratecenter",
+	"missing_did":          "DID was not provided",
+	"invalid_did":          "This is not a valid DID",
+	"missing_account":      "Account was not provided",
+	"invalid_account":      "This is not a valid Account",
+	"missing_client":       "Client was not provided",
+	"invalid_client":       "This is not a valid Client",
+	"no_did":               "There are no DIDs",
+	"no_account":           "There are no accounts",
+	"no_client":            "There are no clients",
+	"no_cdr":               "There are no CDR entries for the filter",
+	"no_sms":               "There are no SMS messages",
+	"used_username":        "You already have a subaccount using this Username",
+}
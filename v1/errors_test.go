@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Categories(t *testing.T) {
+
+	//setup
+	cases := []struct {
+		status     string
+		httpStatus int
+		category   error
+	}{
+		{"invalid_credentials", 200, ErrAuth},
+		{"ip_not_enabled", 200, ErrAuth},
+		{"invalid_ratecenter", 200, ErrValidation},
+		{"missing_did", 200, ErrValidation},
+		{"no_did", 200, ErrNotFound},
+		{"non_sufficient_funds", 200, ErrInsufficientBalance},
+		{"limit_reached", 200, ErrRateLimited},
+		{"", 429, ErrRateLimited},
+		{"", 503, ErrUnavailable},
+		{"", 401, ErrAuth},
+	}
+
+	for _, c := range cases {
+		//execute
+		err := error(newAPIError("getDIDsInfo", c.status, c.httpStatus))
+
+		//verify
+		require.True(t, errors.Is(err, c.category), "%s %d", c.status, c.httpStatus)
+	}
+
+	require.Nil(t, newAPIError("getDIDsInfo", "error", 200).Unwrap())
+}
+
+func TestAPIError_Error(t *testing.T) {
+
+	//execute & verify
+	require.EqualError(t, newAPIError("getBalance", "invalid_credentials", 200), "invalid_credentials")
+	require.EqualError(t, newAPIError("getBalance", "", 502), "502 Bad Gateway")
+	require.Equal(t, "Username or Password is incorrect", newAPIError("getBalance", "invalid_credentials", 200).Description)
+	require.Equal(t, "some new status", newAPIError("getBalance", "some_new_status", 200).Description)
+}
+
+func TestVOIPClient_Get_APIError(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"invalid_credentials"}`)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewGeneralAPI()
+
+	//execute
+	_, err := api.GetBalance(false)
+
+	//verify
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "getBalance", apiErr.Method)
+	require.Equal(t, "invalid_credentials", apiErr.Status)
+	require.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+	require.True(t, errors.Is(err, ErrAuth))
+}
+
+func TestVOIPClient_Post_HTTPError(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "<html>down for maintenance</html>")
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewAccountsAPI()
+
+	//execute
+	err := api.DelSubAccount("12345")
+
+	//verify
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "delSubAccount", apiErr.Method)
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.HTTPStatus)
+	require.True(t, errors.Is(err, ErrUnavailable))
+}
@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//voip.ms sends the same field as a number in one response and a string in the next, sometimes an empty one. The Flex
+//types below decode from either. null and "" decode to the zero value.
+
+//An int that decodes from 5 or "5". "none", which voip.ms sends for unlimited queue limits, decodes to 0.
+type FlexInt int
+
+//A float64 that decodes from 0.01 or "0.0100".
+type FlexFloat float64
+
+//A bool that decodes from true, 1, "1", "true", "yes" or "on", and their opposites. It's sent to voip.ms as 1 or 0.
+type FlexBool bool
+
+//A time.Duration that decodes from "HH:MM:SS", "MM:SS" or a number of seconds, quoted or not. It's encoded back as
+//"HH:MM:SS".
+type FlexDuration time.Duration
+
+func (i FlexInt) Int() int {
+	return int(i)
+}
+
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	s, err := flexString(data)
+	if err != nil || s == "" || strings.EqualFold(s, "none") {
+		*i = 0
+		return err
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		//Whole numbers sometimes come through as "5.0000".
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil || f != float64(int(f)) {
+			return fmt.Errorf("voipms: %q is not an int", s)
+		}
+		n = int(f)
+	}
+
+	*i = FlexInt(n)
+	return nil
+}
+
+func (f FlexFloat) Float64() float64 {
+	return float64(f)
+}
+
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+	s, err := flexString(data)
+	if err != nil || s == "" {
+		*f = 0
+		return err
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("voipms: %q is not a number", s)
+	}
+
+	*f = FlexFloat(n)
+	return nil
+}
+
+func (b FlexBool) Bool() bool {
+	return bool(b)
+}
+
+func (b FlexBool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(b))
+}
+
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	s, err := flexString(data)
+	if err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+func (b FlexBool) MarshalText() ([]byte, error) {
+	if b {
+		return []byte("1"), nil
+	}
+	return []byte("0"), nil
+}
+
+func (b *FlexBool) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "1", "true", "yes", "y", "on", "enabled":
+		*b = true
+	case "0", "false", "no", "n", "off", "disabled", "":
+		*b = false
+	default:
+		return fmt.Errorf("voipms: %q is not a bool", text)
+	}
+	return nil
+}
+
+func (d FlexDuration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func (d FlexDuration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d FlexDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatClock(time.Duration(d)))
+}
+
+func (d *FlexDuration) UnmarshalJSON(data []byte) error {
+	s, err := flexString(data)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ParseClock(s)
+	if err != nil {
+		return err
+	}
+
+	*d = FlexDuration(parsed)
+	return nil
+}
+
+//Returns the raw text of a JSON number or string, or "" for null.
+func flexString(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	return string(data), nil
+}
+
+//Parses "HH:MM:SS", "MM:SS" or seconds, as voip.ms sends durations, into a duration. An empty string is 0.
+func ParseClock(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("voipms: %q is not a duration", s)
+	}
+
+	var d time.Duration
+	for _, p := range parts {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil || n < 0 || strings.HasPrefix(p, "-") {
+			return 0, fmt.Errorf("voipms: %q is not a duration", s)
+		}
+		d = d*60 + time.Duration(n*float64(time.Second))
+	}
+
+	return d, nil
+}
+
+//Formats a duration as voip.ms does, i.e. 04:35:49. Hours go past 24 rather than rolling into days.
+func FormatClock(d time.Duration) string {
+	secs := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
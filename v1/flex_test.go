@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexInt_UnmarshalJSON(t *testing.T) {
+	for in, expected := range map[string]FlexInt{`5`: 5, `"5"`: 5, `" 5 "`: 5, `"5.0000"`: 5, `""`: 0, `null`: 0, `-3`: -3, `"none"`: 0} {
+		var i FlexInt
+		require.NoError(t, json.Unmarshal([]byte(in), &i), in)
+		require.Equal(t, expected, i, in)
+	}
+
+	var i FlexInt
+	require.Error(t, json.Unmarshal([]byte(`"5.5"`), &i))
+	require.Error(t, json.Unmarshal([]byte(`"abc"`), &i))
+}
+
+func TestFlexFloat_UnmarshalJSON(t *testing.T) {
+	for in, expected := range map[string]FlexFloat{`0.01`: 0.01, `"0.0100"`: 0.01, `"-5.5"`: -5.5, `""`: 0, `null`: 0} {
+		var f FlexFloat
+		require.NoError(t, json.Unmarshal([]byte(in), &f), in)
+		require.Equal(t, expected, f, in)
+	}
+
+	var f FlexFloat
+	require.Error(t, json.Unmarshal([]byte(`"n/a"`), &f))
+}
+
+func TestFlexBool_UnmarshalJSON(t *testing.T) {
+	for in, expected := range map[string]FlexBool{
+		`true`: true, `1`: true, `"1"`: true, `"yes"`: true, `"Yes"`: true, `"true"`: true, `"on"`: true,
+		`false`: false, `0`: false, `"0"`: false, `"no"`: false, `""`: false, `null`: false,
+	} {
+		var b FlexBool
+		require.NoError(t, json.Unmarshal([]byte(in), &b), in)
+		require.Equal(t, expected, b, in)
+	}
+
+	var b FlexBool
+	require.Error(t, json.Unmarshal([]byte(`"maybe"`), &b))
+}
+
+func TestFlexBool_Encoding(t *testing.T) {
+
+	//setup
+	rq := struct {
+		CNAM FlexBool `json:"cnam"`
+		SMS  FlexBool `json:"sms"`
+	}{true, false}
+
+	//execute
+	b, errJSON := json.Marshal(rq)
+	values, errValues := EncodeValues(rq)
+
+	//verify
+	require.NoError(t, errJSON)
+	require.Equal(t, `{"cnam":true,"sms":false}`, string(b))
+	require.NoError(t, errValues)
+	require.Equal(t, "1", values.Get("cnam"))
+	require.Equal(t, "0", values.Get("sms"))
+}
+
+func TestFlexDuration_UnmarshalJSON(t *testing.T) {
+	for in, expected := range map[string]time.Duration{
+		`"04:35:49"`:  4*time.Hour + 35*time.Minute + 49*time.Second,
+		`"4:35:49"`:   4*time.Hour + 35*time.Minute + 49*time.Second,
+		`"125:00:01"`: 125*time.Hour + time.Second,
+		`"02:05"`:     2*time.Minute + 5*time.Second,
+		`"16549"`:     16549 * time.Second,
+		`16549`:       16549 * time.Second,
+		`""`:          0,
+		`null`:        0,
+	} {
+		var d FlexDuration
+		require.NoError(t, json.Unmarshal([]byte(in), &d), in)
+		require.Equal(t, expected, d.Duration(), in)
+	}
+
+	var d FlexDuration
+	require.Error(t, json.Unmarshal([]byte(`"1:2:3:4"`), &d))
+	require.Error(t, json.Unmarshal([]byte(`"-00:01"`), &d))
+}
+
+func TestFlexDuration_MarshalJSON(t *testing.T) {
+
+	//setup
+	d := FlexDuration(26*time.Hour + 3*time.Minute + 9*time.Second)
+
+	//execute
+	b, err := json.Marshal(d)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, `"26:03:09"`, string(b))
+}
+
+func TestParseClock(t *testing.T) {
+	for in, expected := range map[string]time.Duration{"04:35:49": 4*time.Hour + 35*time.Minute + 49*time.Second, "01:05": 65 * time.Second, "90": 90 * time.Second, "": 0} {
+		d, err := ParseClock(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expected, d, in)
+	}
+
+	_, err := ParseClock("1:2:3:4")
+	require.Error(t, err)
+	_, err = ParseClock("-00:01")
+	require.Error(t, err)
+
+	require.Equal(t, "26:03:09", FormatClock(26*time.Hour+3*time.Minute+9*time.Second))
+	require.Equal(t, "00:00:01", FormatClock(1400*time.Millisecond))
+}
+
+func TestBalance_UnmarshalJSON(t *testing.T) {
+
+	//setup
+	data := `{"current_balance":"27.1462","spent_total":52.8538,"calls_total":"181","time_total":"4:35:49","spent_today":"0.0000","calls_today":0,"time_today":"0"}`
+
+	//execute
+	b := Balance{}
+	err := json.Unmarshal([]byte(data), &b)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "27.1462", b.CurrentBalance.String())
+	require.Equal(t, "52.8538", b.SpentTotal.String())
+	require.Equal(t, 181, b.CallsTotal.Int())
+	require.Equal(t, 4*time.Hour+35*time.Minute+49*time.Second, b.TimeTotal.Duration())
+	require.Equal(t, 0, b.CallsToday.Int())
+	require.Equal(t, time.Duration(0), b.TimeToday.Duration())
+}
+
+func TestQueue_UnmarshalJSON(t *testing.T) {
+
+	//setup
+	data := `{"queue":"1","priority_weight":"3","member_delay":0,"maximum_wait_time":"none","maximum_callers":"10","ring_inuse":"yes","report_hold_time_agent":"no","agent_ring_timeout":"15","retry_timer":5,"wrapup_time":"0","announce_round_seconds":"30"}`
+
+	//execute
+	q := Queue{}
+	err := json.Unmarshal([]byte(data), &q)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, 3, q.PriorityWeight.Int())
+	require.Equal(t, 0, q.MaximumWaitTime.Int())
+	require.Equal(t, 10, q.MaximumCallers.Int())
+	require.True(t, q.RingInuse.Bool())
+	require.False(t, q.ReportHoldTimeAgent.Bool())
+	require.Equal(t, 15, q.AgentRingTimeout.Int())
+	require.Equal(t, 5, q.RetryTimer.Int())
+	require.Equal(t, 30, q.AnnounceRoundSeconds.Int())
+}
+
+func TestAccount_LockInternational(t *testing.T) {
+	for _, data := range []string{`{"lock_international":1}`, `{"lock_international":"1"}`} {
+		a := Account{}
+		require.NoError(t, json.Unmarshal([]byte(data), &a), data)
+		require.Equal(t, 1, a.LockInternational.Int(), data)
+	}
+
+	values, err := EncodeValues(&Account{LockInternational: 1})
+	require.NoError(t, err)
+	require.Equal(t, "1", values.Get("lock_international"))
+}
@@ -1,10 +1,9 @@
 package v1
 
 import (
-	"net/url"
+	"context"
 	"time"
 	"errors"
-	"encoding/json"
 )
 
 type GeneralAPI struct {
@@ -17,13 +16,13 @@ type GetBalanceResp struct {
 }
 
 type Balance struct {
-	CurrentBalance json.Number `json:"current_balance"`
-	SpentTotal     json.Number `json:"spent_total,omitempty"`
-	CallsTotal     json.Number `json:"calls_total,omitempty"`
-	TimeTotal      json.Number `json:"time_total,omitempty"` //TODO:Stan change to duration
-	SpentToday     json.Number `json:"spent_today,omitempty"`
-	CallsToday     int `json:"calls_today,omitempty"`
-	TimeToday      json.Number `json:"time_today,omitempty"`
+	CurrentBalance Money `json:"current_balance"`
+	SpentTotal     Money `json:"spent_total,omitempty"`
+	CallsTotal     FlexInt `json:"calls_total,omitempty"`
+	TimeTotal      FlexDuration `json:"time_total,omitempty"`
+	SpentToday     Money `json:"spent_today,omitempty"`
+	CallsToday     FlexInt `json:"calls_today,omitempty"`
+	TimeToday      FlexDuration `json:"time_today,omitempty"`
 }
 
 type GetCountriesResp struct {
@@ -69,18 +68,21 @@ type Transaction struct {
 	UniqueId    string `json:"uniqueid"`
 	Type        string `json:"type"`
 	Description string `json:"description"`
-	Amount      string `json:"amount"`
+	Amount      Money `json:"amount"`
 }
 
 func (g *GeneralAPI) GetBalance(advanced bool) (*Balance, error) {
+	return g.GetBalanceContext(context.Background(), advanced)
+}
 
-	values := url.Values{}
-	if advanced {
-		values.Add("advanced", "true")
-	}
+func (g *GeneralAPI) GetBalanceContext(ctx context.Context, advanced bool) (*Balance, error) {
+
+	rq := struct {
+		Advanced bool `url:"advanced,omitempty"`
+	}{advanced}
 
 	rs := &GetBalanceResp{}
-	if err := g.client.Get("getBalance", values, rs); err != nil {
+	if err := g.client.GetContext(ctx, "getBalance", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -88,13 +90,16 @@ func (g *GeneralAPI) GetBalance(advanced bool) (*Balance, error) {
 }
 
 func (g *GeneralAPI) GetCountries(country string) ([]Country, error) {
-	values := url.Values{}
-	if country != "" {
-		values.Add("country", country)
-	}
+	return g.GetCountriesContext(context.Background(), country)
+}
+
+func (g *GeneralAPI) GetCountriesContext(ctx context.Context, country string) ([]Country, error) {
+	rq := struct {
+		Country string `url:"country,omitempty"`
+	}{country}
 
 	rs := &GetCountriesResp{}
-	if err := g.client.Get("getCountries", values, rs); err != nil {
+	if err := g.client.GetContext(ctx, "getCountries", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -102,8 +107,12 @@ func (g *GeneralAPI) GetCountries(country string) ([]Country, error) {
 }
 
 func (g *GeneralAPI) GetIP() (string, error) {
+	return g.GetIPContext(context.Background())
+}
+
+func (g *GeneralAPI) GetIPContext(ctx context.Context) (string, error) {
 	respStruct := &GetIPResp{}
-	if err := g.client.Get("getIP", url.Values{}, respStruct); err != nil {
+	if err := g.client.GetContext(ctx, "getIP", nil, respStruct); err != nil {
 		return "", err
 	}
 
@@ -111,13 +120,16 @@ func (g *GeneralAPI) GetIP() (string, error) {
 }
 
 func (g *GeneralAPI) GetLanguages(language string) ([]Language, error) {
-	values := url.Values{}
-	if language != "" {
-		values.Add("language", language)
-	}
+	return g.GetLanguagesContext(context.Background(), language)
+}
+
+func (g *GeneralAPI) GetLanguagesContext(ctx context.Context, language string) ([]Language, error) {
+	rq := struct {
+		Language string `url:"language,omitempty"`
+	}{language}
 
 	rs := &GetLanguagesResp{}
-	if err := g.client.Get("getLanguages", values, rs); err != nil {
+	if err := g.client.GetContext(ctx, "getLanguages", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -125,13 +137,16 @@ func (g *GeneralAPI) GetLanguages(language string) ([]Language, error) {
 }
 
 func (g *GeneralAPI) GetServerInfo(serverPop string) ([]Server, error) {
-	values := url.Values{}
-	if serverPop != "" {
-		values.Add("server_pop", serverPop)
-	}
+	return g.GetServerInfoContext(context.Background(), serverPop)
+}
+
+func (g *GeneralAPI) GetServerInfoContext(ctx context.Context, serverPop string) ([]Server, error) {
+	rq := struct {
+		ServerPop string `url:"server_pop,omitempty"`
+	}{serverPop}
 
 	rs := &GetServerInfoResp{}
-	if err := g.client.Get("getServersInfo", values, rs); err != nil {
+	if err := g.client.GetContext(ctx, "getServersInfo", rq, rs); err != nil {
 		return nil, err
 	}
 
@@ -139,19 +154,25 @@ func (g *GeneralAPI) GetServerInfo(serverPop string) ([]Server, error) {
 }
 
 func (g *GeneralAPI) GetTransactionHistory(dateFrom, dateTo time.Time) ([]Transaction, error) {
-	values := url.Values{}
+	return g.GetTransactionHistoryContext(context.Background(), dateFrom, dateTo)
+}
+
+func (g *GeneralAPI) GetTransactionHistoryContext(ctx context.Context, dateFrom, dateTo time.Time) ([]Transaction, error) {
 	if dateFrom.IsZero() {
 		return nil, errors.New("dateFrom is required!")
 	}
-	values.Add("date_from", dateFrom.Format("2006-01-02 15:04:05"))
 
 	if dateTo.IsZero() {
 		return nil, errors.New("dateTo is required!")
 	}
-	values.Add("date_to", dateTo.Format("2006-01-02 15:04:05"))
+
+	rq := struct {
+		DateFrom string `url:"date_from"`
+		DateTo   string `url:"date_to"`
+	}{dateFrom.Format("2006-01-02 15:04:05"), dateTo.Format("2006-01-02 15:04:05")}
 
 	rs := &GetTransactionHistoryResp{}
-	if err := g.client.Get("getTransactionHistory", values, rs); err != nil {
+	if err := g.client.GetContext(ctx, "getTransactionHistory", rq, rs); err != nil {
 		return nil, err
 	}
 
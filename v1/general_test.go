@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 func TestGeneralAPI_GetBalance(t *testing.T) {
@@ -14,17 +15,17 @@ func TestGeneralAPI_GetBalance(t *testing.T) {
 	//setup
 	rq := GetBalanceResp {
 		BaseResp{"success"},
-		Balance{CurrentBalance: "100"},
+		Balance{CurrentBalance: MustParseMoney("100")},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getBalance"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getBalance"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	balance, err := api.GetBalance(false)
@@ -44,12 +45,12 @@ func TestGeneralAPI_GetBalance_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getBalance"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getBalance"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	balance, err := api.GetBalance(false)
@@ -64,18 +65,18 @@ func TestGeneralAPI_GetBalance_True(t *testing.T) {
 	//setup
 	rq := GetBalanceResp {
 		BaseResp{"success"},
-		Balance{"100", "0.0", "0.1", "60", "0.2", 1, "55"},
+		Balance{MustParseMoney("100"), Money{}, 1, FlexDuration(60 * time.Second), MustParseMoney("0.2"), 1, FlexDuration(55 * time.Second)},
 	}
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getBalance"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"true"}, r.URL.Query()["advanced"])
+		require.Equal(t, []string{"getBalance"}, formValues(r)["method"])
+		require.Equal(t, []string{"true"}, formValues(r)["advanced"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	balance, err := api.GetBalance(true)
@@ -95,12 +96,12 @@ func TestGeneralAPI_GetCountries(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCountries"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCountries"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	countries, err := api.GetCountries("")
@@ -121,12 +122,12 @@ func TestGeneralAPI_GetCountries_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCountries"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getCountries"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	countries, err := api.GetCountries("")
@@ -146,13 +147,13 @@ func TestGeneralAPI_GetCountries_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getCountries"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"CA"}, r.URL.Query()["country"])
+		require.Equal(t, []string{"getCountries"}, formValues(r)["method"])
+		require.Equal(t, []string{"CA"}, formValues(r)["country"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	countries, err := api.GetCountries("CA")
@@ -173,12 +174,12 @@ func TestGeneralAPI_GetIP(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getIP"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getIP"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	ip, err := api.GetIP()
@@ -198,12 +199,12 @@ func TestGeneralAPI_GetIP_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getIP"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getIP"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	ip, err := api.GetIP()
@@ -223,12 +224,12 @@ func TestGeneralAPI_GetLanguages(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLanguages"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getLanguages"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	languages, err := api.GetLanguages("")
@@ -249,12 +250,12 @@ func TestGeneralAPI_GetLanguages_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLanguages"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getLanguages"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	languages, err := api.GetLanguages("")
@@ -274,13 +275,13 @@ func TestGeneralAPI_GetLanguages_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getLanguages"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"en"}, r.URL.Query()["language"])
+		require.Equal(t, []string{"getLanguages"}, formValues(r)["method"])
+		require.Equal(t, []string{"en"}, formValues(r)["language"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	languages, err := api.GetLanguages("en")
@@ -302,12 +303,12 @@ func TestGeneralAPI_GetServerInfo(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getServersInfo"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getServersInfo"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	servers, err := api.GetServerInfo("")
@@ -328,12 +329,12 @@ func TestGeneralAPI_GetServerInfo_Error(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getServersInfo"}, r.URL.Query()["method"])
+		require.Equal(t, []string{"getServersInfo"}, formValues(r)["method"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	servers, err := api.GetServerInfo("")
@@ -353,13 +354,13 @@ func TestGeneralAPI_GetServerInfo_Specified(t *testing.T) {
 	result, _ := json.Marshal(rq)
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, []string{"getServersInfo"}, r.URL.Query()["method"])
-		require.Equal(t, []string{"ServerName1"}, r.URL.Query()["server_pop"])
+		require.Equal(t, []string{"getServersInfo"}, formValues(r)["method"])
+		require.Equal(t, []string{"ServerName1"}, formValues(r)["server_pop"])
 		fmt.Fprintln(w, string(result))
 	}))
 	defer ts.Close()
 
-	api := NewVOIPClient(ts.URL, "", "", true).NewGeneralAPI()
+	api := NewVOIPClient(ts.URL, "", "", WithDebug(true)).NewGeneralAPI()
 
 	//execute
 	servers, err := api.GetServerInfo("ServerName1")
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+//Replaces secrets in debug output and recorded fixtures.
+const Redacted = "[REDACTED]"
+
+//Structured debug logger. keyvals alternate between a string key and its value.
+type Logger interface {
+	Log(msg string, keyvals ...interface{})
+}
+
+//Adapts a function to Logger.
+type LoggerFunc func(msg string, keyvals ...interface{})
+
+func (f LoggerFunc) Log(msg string, keyvals ...interface{}) {
+	f(msg, keyvals...)
+}
+
+//Logs through the standard library logger as: msg key=value key=value
+var StdLogger Logger = LoggerFunc(func(msg string, keyvals ...interface{}) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+	}
+	log.Println(b.String())
+})
+
+//Logs every request and response, redacted unless WithRawDump is also passed.
+func WithDebug(debug bool) Option {
+	return func(c *VOIPClient) {
+		c.Debug = debug
+	}
+}
+
+//Sends debug output to logger instead of StdLogger. Output is still only written when Debug is true.
+func WithLogger(logger Logger) Option {
+	return func(c *VOIPClient) {
+		c.logger = logger
+	}
+}
+
+//Logs complete request dumps and response bodies without redacting credentials or passwords. Only meant for
+//troubleshooting against test accounts.
+func WithRawDump(raw bool) Option {
+	return func(c *VOIPClient) {
+		c.rawDump = raw
+	}
+}
+
+func (c *VOIPClient) log(msg string, keyvals ...interface{}) {
+	if !c.Debug {
+		return
+	}
+
+	if c.logger != nil {
+		c.logger.Log(msg, keyvals...)
+		return
+	}
+	StdLogger.Log(msg, keyvals...)
+}
+
+//True for credentials and password like parameter or JSON field names.
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	return name == "api_username" || name == "pin" || strings.Contains(name, "password") || strings.Contains(name, "secret")
+}
+
+//Returns a copy of values with secrets redacted.
+func RedactValues(values url.Values) url.Values {
+	masked := url.Values{}
+	for k, v := range values {
+		if IsSecret(k) {
+			masked[k] = []string{Redacted}
+			continue
+		}
+		masked[k] = append([]string(nil), v...)
+	}
+	return masked
+}
+
+//Redacts secret fields anywhere in a JSON document. Bodies that aren't JSON are returned as is.
+func RedactJSON(body []byte) []byte {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return body
+	}
+
+	out, err := json.Marshal(redactDocument(doc))
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func redactDocument(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if IsSecret(k) {
+				v[k] = Redacted
+			} else {
+				v[k] = redactDocument(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactDocument(child)
+		}
+	}
+	return doc
+}
+
+//Recovers the parameters sent with req from its query string and form body without consuming the body.
+func RequestParams(req *http.Request) url.Values {
+	values := url.Values{}
+	for k, v := range req.URL.Query() {
+		values[k] = v
+	}
+
+	if req.GetBody == nil {
+		return values
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return values
+	}
+	defer body.Close()
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+		if err != nil {
+			return values
+		}
+		for k, v := range form.Value {
+			values[k] = append(values[k], v...)
+		}
+	case "application/x-www-form-urlencoded":
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return values
+		}
+		form, _ := url.ParseQuery(string(b))
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (r *recordingLogger) Log(msg string, keyvals ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprint(append([]interface{}{msg}, keyvals...)...))
+}
+
+func (r *recordingLogger) String() string {
+	return strings.Join(r.lines, "\n")
+}
+
+func TestLogger_RedactsGet(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success","accounts":[{"account":"100000_a","password":"SubPass1"}]}`)
+	}))
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithDebug(true), WithLogger(logger)).NewAccountsAPI()
+
+	//execute
+	accounts, err := api.GetSubAccounts("")
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "SubPass1", accounts[0].Password)
+	require.Len(t, logger.lines, 2)
+	require.NotContains(t, logger.String(), "me@example.com")
+	require.NotContains(t, logger.String(), "ApiPass1")
+	require.NotContains(t, logger.String(), "SubPass1")
+	require.Contains(t, logger.String(), "getSubAccounts")
+	require.Contains(t, logger.String(), "100000_a")
+}
+
+func TestLogger_RedactsPost(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success"}`)
+	}))
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithDebug(true), WithLogger(logger)).NewClientsAPI()
+
+	//execute
+	err := api.SignupClient(&Client{Email: "client@example.com", Password: "ClientPass1"}, "client@example.com", "ClientPass1", true)
+
+	//verify
+	require.NoError(t, err)
+	require.NotContains(t, logger.String(), "ApiPass1")
+	require.NotContains(t, logger.String(), "ClientPass1")
+	require.Contains(t, logger.String(), "signupClient")
+	require.Contains(t, logger.String(), "client@example.com")
+}
+
+func TestLogger_RawDump(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithDebug(true), WithLogger(logger), WithRawDump(true)).NewGeneralAPI()
+
+	//execute
+	_, err := api.GetIP()
+
+	//verify
+	require.NoError(t, err)
+	require.Contains(t, logger.String(), "ApiPass1")
+}
+
+func TestLogger_DebugOff(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	api := NewVOIPClient(ts.URL, "", "", WithLogger(logger)).NewGeneralAPI()
+
+	//execute
+	_, err := api.GetIP()
+
+	//verify
+	require.NoError(t, err)
+	require.Len(t, logger.lines, 0)
+}
+
+func TestRedactValues(t *testing.T) {
+
+	//setup
+	values := url.Values{
+		"api_username":   {"me@example.com"},
+		"api_password":   {"ApiPass1"},
+		"queue_password": {"1234"},
+		"pin":            {"4321"},
+		"did":            {"5555551234"},
+	}
+
+	//execute
+	masked := RedactValues(values)
+
+	//verify
+	require.Equal(t, Redacted, masked.Get("api_username"))
+	require.Equal(t, Redacted, masked.Get("api_password"))
+	require.Equal(t, Redacted, masked.Get("queue_password"))
+	require.Equal(t, Redacted, masked.Get("pin"))
+	require.Equal(t, "5555551234", masked.Get("did"))
+	require.Equal(t, "ApiPass1", values.Get("api_password"))
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+//A single voip.ms method call as seen by Middleware.
+type Invocation struct {
+	Method string
+	Params url.Values //Copy of the parameters with credentials and secrets Redacted. Changing it has no effect.
+
+	//Filled in once the call has been sent.
+	HTTPStatus int
+	Status     string          //voip.ms status, i.e. success or invalid_did.
+	Response   json.RawMessage //Raw response body. A Middleware can set it and skip next to answer on voip.ms's behalf.
+	Latency    time.Duration   //Time spent sending, including retries and waiting on the rate limiter.
+
+	params url.Values
+	post   bool
+}
+
+//Sends an Invocation. The returned error is the one the API function returns, usually an *APIError.
+type Handler func(ctx context.Context, inv *Invocation) error
+
+//Wraps a Handler to observe or change calls, i.e. for tracing, metrics, audit logging or fault injection.
+type Middleware func(next Handler) Handler
+
+//Wraps every call in middleware. The first Middleware given is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *VOIPClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_Observes(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"invalid_did"}`)
+	}))
+	defer ts.Close()
+
+	var order []string
+	var seen *Invocation
+	var seenErr error
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, inv *Invocation) error {
+				order = append(order, name)
+				err := next(ctx, inv)
+				order = append(order, name)
+				seen, seenErr = inv, err
+				return err
+			}
+		}
+	}
+
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithMiddleware(trace("outer"), trace("inner"))).NewDIDsAPI()
+
+	//execute
+	_, err := api.GetDIDsInfo("", "5555551234")
+
+	//verify
+	require.EqualError(t, err, "invalid_did")
+	require.Equal(t, []string{"outer", "inner", "inner", "outer"}, order)
+	require.Equal(t, "getDIDsInfo", seen.Method)
+	require.Equal(t, "5555551234", seen.Params.Get("did"))
+	require.Equal(t, "", seen.Params.Get("api_password"))
+	require.Equal(t, http.StatusOK, seen.HTTPStatus)
+	require.Equal(t, "invalid_did", seen.Status)
+	require.True(t, seen.Latency > 0)
+	require.True(t, errors.Is(seenErr, ErrValidation))
+}
+
+func TestMiddleware_FaultInjection(t *testing.T) {
+
+	//setup
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer ts.Close()
+
+	fake := func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) error {
+			if inv.Method == "getIP" {
+				inv.Response = []byte(`{"status":"success","ip":"10.0.0.1"}`)
+				return nil
+			}
+			return newAPIError(inv.Method, "limit_reached", http.StatusOK)
+		}
+	}
+
+	c := NewVOIPClient(ts.URL, "", "", WithMiddleware(fake))
+
+	//execute
+	ip, errIP := c.NewGeneralAPI().GetIP()
+	_, errBalance := c.NewGeneralAPI().GetBalance(false)
+
+	//verify
+	require.NoError(t, errIP)
+	require.Equal(t, "10.0.0.1", ip)
+	require.True(t, errors.Is(errBalance, ErrRateLimited))
+	require.Equal(t, 0, calls)
+}
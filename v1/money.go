@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//Number of Money units in a dollar. voip.ms works to 4 decimal places.
+const MoneyScale = 10000
+
+//An exact amount of money to voip.ms's 4 decimal places, i.e. 27.1462. Arithmetic and comparisons are done on whole
+//units so totals over thousands of CDRs don't pick up float rounding. The zero value is 0.0000.
+//
+//It decodes from a JSON number or string and encodes as a string, the way voip.ms sends it.
+type Money struct {
+	units int64
+}
+
+//Returns the Money for a whole number of 1/10000ths of a dollar.
+func MoneyFromUnits(units int64) Money {
+	return Money{units}
+}
+
+//Rounds f to the nearest unit, halves away from zero. Only for values that were floats to begin with, parse strings
+//with ParseMoney.
+func MoneyFromFloat(f float64) Money {
+	return Money{int64(math.Round(f * MoneyScale))}
+}
+
+//Parses amounts like "27.1462", "-0.00090000", "5" or "1e-3". Digits past the 4th decimal place are rounded, halves
+//away from zero. An empty string is 0.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, nil
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Money{}, fmt.Errorf("voipms: %q is not an amount of money", s)
+	}
+
+	r.Mul(r, big.NewRat(MoneyScale, 1))
+	units, ok := roundRat(r)
+	if !ok {
+		return Money{}, fmt.Errorf("voipms: %q is out of range", s)
+	}
+
+	return Money{units}, nil
+}
+
+//Like ParseMoney but panics on error. For constants.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+//Rounds r to the nearest integer, halves away from zero.
+func roundRat(r *big.Rat) (int64, bool) {
+	num := new(big.Int).Abs(r.Num())
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64(), q.IsInt64()
+}
+
+func (m Money) Units() int64 {
+	return m.units
+}
+
+func (m Money) Float64() float64 {
+	return float64(m.units) / MoneyScale
+}
+
+func (m Money) Add(o Money) Money {
+	return Money{m.units + o.units}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{m.units - o.units}
+}
+
+func (m Money) Neg() Money {
+	return Money{-m.units}
+}
+
+func (m Money) Abs() Money {
+	if m.units < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+//Multiplies by a whole quantity, i.e. a number of DIDs.
+func (m Money) Mul(n int64) Money {
+	return Money{m.units * n}
+}
+
+//Multiplies by num/den, rounding the result to the nearest unit, halves away from zero. i.e. a per minute rate for
+//95 seconds is rate.MulRatio(95, 60).
+func (m Money) MulRatio(num, den int64) Money {
+	if den == 0 {
+		panic("voipms: Money.MulRatio by zero")
+	}
+	product := new(big.Int).Mul(big.NewInt(m.units), big.NewInt(num))
+	units, _ := roundRat(new(big.Rat).SetFrac(product, big.NewInt(den)))
+	return Money{units}
+}
+
+//Returns -1, 0 or 1 as m is less than, equal to or more than o.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.units < o.units:
+		return -1
+	case m.units > o.units:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+//Returns -1, 0 or 1 as m is negative, zero or positive.
+func (m Money) Sign() int {
+	return m.Cmp(Money{})
+}
+
+//Formats to 4 decimal places, i.e. 27.1462 or -0.0009.
+func (m Money) String() string {
+	sign := ""
+	units := m.units
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	return fmt.Sprintf("%s%d.%04d", sign, units/MoneyScale, units%MoneyScale)
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(text []byte) error {
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s, err := flexString(data)
+	if err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
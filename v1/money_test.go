@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	for in, expected := range map[string]string{
+		"27.1462":    "27.1462",
+		"0.00900000": "0.0090",
+		"5":          "5.0000",
+		"-0.0009":    "-0.0009",
+		"0.00005":    "0.0001",
+		"-0.00005":   "-0.0001",
+		"0.00004999": "0.0000",
+		"1e-3":       "0.0010",
+		" 2.5 ":      "2.5000",
+		"":           "0.0000",
+	} {
+		m, err := ParseMoney(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expected, m.String(), in)
+	}
+
+	_, err := ParseMoney("$5")
+	require.Error(t, err)
+	_, err = ParseMoney("1e30")
+	require.Error(t, err)
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+
+	//setup
+	a := MustParseMoney("10.0100")
+	b := MustParseMoney("0.0200")
+
+	//execute & verify
+	require.Equal(t, "10.0300", a.Add(b).String())
+	require.Equal(t, "-9.9900", b.Sub(a).String())
+	require.Equal(t, "9.9900", b.Sub(a).Abs().String())
+	require.Equal(t, "0.0600", b.Mul(3).String())
+	require.Equal(t, 1, a.Cmp(b))
+	require.Equal(t, -1, b.Cmp(a))
+	require.Equal(t, 0, a.Cmp(MoneyFromUnits(100100)))
+	require.Equal(t, -1, b.Neg().Sign())
+	require.True(t, Money{}.IsZero())
+	require.Equal(t, 10.01, a.Float64())
+	require.Equal(t, MoneyFromFloat(0.1).Add(MoneyFromFloat(0.2)), MustParseMoney("0.3"))
+}
+
+func TestMoney_MulRatio(t *testing.T) {
+	rate := MustParseMoney("0.0100")
+
+	require.Equal(t, "0.0158", rate.MulRatio(95, 60).String())
+	require.Equal(t, "0.0003", MustParseMoney("0.0005").MulRatio(1, 2).String())
+	require.Equal(t, "-0.0003", MustParseMoney("-0.0005").MulRatio(1, 2).String())
+	require.Panics(t, func() { rate.MulRatio(1, 0) })
+}
+
+func TestMoney_JSON(t *testing.T) {
+
+	//setup
+	var rs struct {
+		Number Money `json:"number"`
+		String Money `json:"string"`
+		Empty  Money `json:"empty"`
+		Null   Money `json:"null"`
+	}
+
+	//execute
+	err := json.Unmarshal([]byte(`{"number":0.01,"string":"-27.14620000","empty":"","null":null}`), &rs)
+	b, merr := json.Marshal(rs)
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, int64(100), rs.Number.Units())
+	require.Equal(t, int64(-271462), rs.String.Units())
+	require.True(t, rs.Empty.IsZero())
+	require.True(t, rs.Null.IsZero())
+	require.NoError(t, merr)
+	require.Equal(t, `{"number":"0.0100","string":"-27.1462","empty":"0.0000","null":"0.0000"}`, string(b))
+
+	require.Error(t, json.Unmarshal([]byte(`{"number":"abc"}`), &rs))
+}
+
+func TestMoney_EncodeValues(t *testing.T) {
+
+	//execute
+	values, err := EncodeValues(AddChargeReq{Client: "1", Charge: MustParseMoney("5.5")})
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "5.5000", values.Get("charge"))
+}
+
+func TestMoney_DIDs(t *testing.T) {
+
+	//setup
+	info := DIDInfo{}
+	order := &DIDOrder{Did: "5555551234", DIDOrderResellerConfig: DIDOrderResellerConfig{
+		Account: "100000_client", Monthly: MustParseMoney("1.5"), Setup: MustParseMoney("0.5"), Minute: MustParseMoney("0.01"),
+	}}
+
+	//execute
+	err := json.Unmarshal([]byte(`{"did":"5555551234","reseller_monthly":"1.50000000","reseller_minute":0.01,"reseller_setup":""}`), &info)
+	values, verr := EncodeValues(order)
+	empty, eerr := EncodeValues(&DIDOrder{Did: "5555551234"})
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "1.5000", info.ResellerMonthly.String())
+	require.Equal(t, "0.0100", info.ResellerMinute.String())
+	require.True(t, info.ResellerSetup.IsZero())
+
+	require.NoError(t, verr)
+	require.Equal(t, "1.5000", values.Get("monthly"))
+	require.Equal(t, "0.5000", values.Get("setup"))
+	require.Equal(t, "0.0100", values.Get("minute"))
+
+	require.NoError(t, eerr)
+	_, ok := empty["monthly"]
+	require.False(t, ok)
+}
+
+func TestMoney_ConnectDID(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := formValues(r)
+		require.Equal(t, "connectDID", values.Get("method"))
+		require.Equal(t, "2.0000", values.Get("monthly"))
+		require.Equal(t, "0.0000", values.Get("setup"))
+		require.Equal(t, "0.0090", values.Get("minute"))
+		fmt.Fprintln(w, `{"status":"success"}`)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewDIDsAPI()
+
+	//execute
+	err := api.ConnectDID("5555551234", "100000_client", MustParseMoney("2"), Money{}, MustParseMoney("0.009"), time.Time{}, false, false)
+
+	//verify
+	require.NoError(t, err)
+}
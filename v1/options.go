@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+//Default timeout applied to the whole request, including reading the response body.
+const DefaultTimeout = 60 * time.Second
+
+//Configures a VOIPClient when passed to NewVOIPClient.
+type Option func(*VOIPClient)
+
+//Pooled transport shared by clients that don't supply their own so connections are kept alive and reused.
+var defaultTransport http.RoundTripper = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   10,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+var sharedHTTPClient = newDefaultHTTPClient()
+
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: defaultTransport,
+		Timeout:   DefaultTimeout,
+	}
+}
+
+//Sends requests with hc as is. Use this for custom TLS, proxies, cookie jars, etc.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *VOIPClient) {
+		if hc != nil {
+			c.client = hc
+		}
+	}
+}
+
+//Sends requests through rt, keeping the current timeout.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *VOIPClient) {
+		hc := *c.HTTPClient()
+		hc.Transport = rt
+		c.client = &hc
+	}
+}
+
+//Overrides DefaultTimeout. Zero means no timeout, leaving deadlines entirely to the request context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *VOIPClient) {
+		hc := *c.HTTPClient()
+		hc.Timeout = d
+		c.client = &hc
+	}
+}
+
+//Sends read methods as GET requests with every parameter, credentials included, in the query string. By default
+//every method is sent as a form encoded POST so nothing ends up in proxy or access logs.
+func WithGetRequests() Option {
+	return func(c *VOIPClient) {
+		c.useGet = true
+	}
+}
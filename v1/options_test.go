@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	count int
+	next  http.RoundTripper
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count++
+	return c.next.RoundTrip(req)
+}
+
+func TestNewVOIPClient_Defaults(t *testing.T) {
+
+	//execute
+	c := NewVOIPClient("http://localhost", "", "")
+
+	//verify
+	require.Equal(t, DefaultTimeout, c.HTTPClient().Timeout)
+	require.Equal(t, defaultTransport, c.HTTPClient().Transport)
+	require.Equal(t, sharedHTTPClient, (&VOIPClient{}).HTTPClient())
+}
+
+func TestNewVOIPClient_WithHTTPClient(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+	defer ts.Close()
+
+	rt := &countingTransport{next: http.DefaultTransport}
+	hc := &http.Client{Transport: rt}
+
+	api := NewVOIPClient(ts.URL, "", "", WithHTTPClient(hc)).NewGeneralAPI()
+
+	//execute
+	ip, err := api.GetIP()
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ip)
+	require.Equal(t, 1, rt.count)
+}
+
+func TestNewVOIPClient_WithTransportAndTimeout(t *testing.T) {
+
+	//setup
+	rt := &countingTransport{next: http.DefaultTransport}
+
+	//execute
+	c := NewVOIPClient("http://localhost", "", "", WithTransport(rt), WithTimeout(time.Second))
+
+	//verify
+	require.Equal(t, rt, c.HTTPClient().Transport)
+	require.Equal(t, time.Second, c.HTTPClient().Timeout)
+	require.Equal(t, DefaultTimeout, sharedHTTPClient.Timeout)
+}
+
+func TestNewVOIPClient_WithTimeout_Exceeded(t *testing.T) {
+
+	//setup
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	api := NewVOIPClient(ts.URL, "", "", WithTimeout(50*time.Millisecond)).NewGeneralAPI()
+
+	//execute
+	ip, err := api.GetIP()
+
+	//verify
+	require.Error(t, err)
+	require.Equal(t, "", ip)
+}
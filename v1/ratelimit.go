@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+//Waits until a request for method may be sent. Implementations must be safe for concurrent use.
+type Limiter interface {
+	Wait(ctx context.Context, method string) error
+}
+
+//Token bucket Limiter with a global rate and optional per method rates. Per method rates apply on top of the global
+//one, so a method has to get a token from both. Share one between clients (and goroutines) to keep them all under a
+//single budget.
+type RateLimiter struct {
+	mu      sync.Mutex
+	global  *bucket
+	methods map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	rate   float64 //tokens per second.
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+//A perSecond of 0 or less means no global limit.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	l := &RateLimiter{
+		methods: map[string]*bucket{},
+	}
+	l.global = l.newBucket(perSecond, burst)
+	return l
+}
+
+//Limits every request sent through the client with limiter.
+func WithRateLimiter(limiter Limiter) Option {
+	return func(c *VOIPClient) {
+		c.limiter = limiter
+	}
+}
+
+//Limits method, i.e. getCDR, to perSecond on top of the global limit. A perSecond of 0 or less removes the method limit.
+func (l *RateLimiter) SetMethodLimit(method string, perSecond float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if perSecond <= 0 {
+		delete(l.methods, method)
+		return
+	}
+	l.methods[method] = l.newBucket(perSecond, burst)
+}
+
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := l.clock()
+	buckets := []*bucket{l.global, l.methods[method]}
+
+	var wait time.Duration
+	for _, b := range buckets {
+		if b != nil {
+			if d := b.take(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		//Hand the tokens back so a cancelled caller doesn't eat into everybody else's budget.
+		l.mu.Lock()
+		for _, b := range buckets {
+			if b != nil {
+				b.tokens = math.Min(b.tokens+1, b.burst)
+			}
+		}
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) newBucket(perSecond float64, burst int) *bucket {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   l.clock(),
+	}
+}
+
+//Takes a token, going into debt if there isn't one, and returns how long until that debt is paid off.
+func (b *bucket) take(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed*b.rate, b.burst)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
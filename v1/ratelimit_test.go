@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Bucket(t *testing.T) {
+
+	//setup
+	now := time.Now()
+	l := NewRateLimiter(2, 2)
+	l.now = func() time.Time { return now }
+	l.global.last = now
+
+	//execute & verify
+	b := l.global
+	require.Equal(t, time.Duration(0), b.take(now))
+	require.Equal(t, time.Duration(0), b.take(now))
+	require.Equal(t, 500*time.Millisecond, b.take(now))
+	require.Equal(t, time.Second, b.take(now))
+
+	now = now.Add(time.Second) //pays back the debt of 2 tokens
+	require.Equal(t, 500*time.Millisecond, b.take(now))
+
+	now = now.Add(time.Hour) //never more than burst
+	require.Equal(t, time.Duration(0), b.take(now))
+	require.Equal(t, time.Duration(0), b.take(now))
+	require.Equal(t, 500*time.Millisecond, b.take(now))
+}
+
+func TestRateLimiter_MethodLimit(t *testing.T) {
+
+	//setup
+	l := NewRateLimiter(0, 0)
+	l.SetMethodLimit("getCDR", 1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	//execute & verify
+	require.NoError(t, l.Wait(ctx, "getCDR"))
+	require.NoError(t, l.Wait(ctx, "getBalance")) //no global limit
+	require.Equal(t, context.DeadlineExceeded, l.Wait(ctx, "getCDR"))
+	require.InDelta(t, 0.0, l.methods["getCDR"].tokens, 0.01) //refunded
+}
+
+func TestRateLimiter_Shared(t *testing.T) {
+
+	//setup
+	var mu sync.Mutex
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+	defer ts.Close()
+
+	limiter := NewRateLimiter(100, 5)
+	api := NewVOIPClient(ts.URL, "", "", WithRateLimiter(limiter)).NewGeneralAPI()
+
+	//execute
+	start := time.Now()
+	errs := make(chan error, 15)
+	var wg sync.WaitGroup
+	for i := 0; i < 15; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.GetIP()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	//verify
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 15, calls)
+	require.True(t, time.Since(start) >= 90*time.Millisecond) //10 requests past the burst at 100/s
+}
@@ -0,0 +1,228 @@
+package rating
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//A Lookup over a whole rate deck. Rates are kept in a prefix trie so Match takes time in the length of the number, not
+//the size of the deck. It isn't safe for concurrent use while rates are being added.
+type RateDeck struct {
+	root node
+	size int
+}
+
+type node struct {
+	children map[byte]*node
+	rate     *Rate
+}
+
+func NewRateDeck(rates []Rate) *RateDeck {
+	d := &RateDeck{}
+	for _, r := range rates {
+		d.Add(r)
+	}
+	return d
+}
+
+//Adds rate, replacing any rate with the same prefix.
+func (d *RateDeck) Add(rate Rate) {
+	n := &d.root
+	for i := 0; i < len(rate.Prefix); i++ {
+		if n.children == nil {
+			n.children = map[byte]*node{}
+		}
+		child, ok := n.children[rate.Prefix[i]]
+		if !ok {
+			child = &node{}
+			n.children[rate.Prefix[i]] = child
+		}
+		n = child
+	}
+
+	if n.rate == nil {
+		d.size++
+	}
+	n.rate = &rate
+}
+
+//Returns the rate with the longest prefix of number.
+func (d *RateDeck) Match(number string) (Rate, bool) {
+	var best *Rate
+	n := &d.root
+	for i := 0; n != nil; i++ {
+		if n.rate != nil {
+			best = n.rate
+		}
+		if i == len(number) {
+			break
+		}
+		n = n.children[number[i]]
+	}
+
+	if best == nil {
+		return Rate{}, false
+	}
+	return *best, true
+}
+
+//Returns the rate for exactly prefix.
+func (d *RateDeck) Get(prefix string) (Rate, bool) {
+	n := &d.root
+	for i := 0; i < len(prefix) && n != nil; i++ {
+		n = n.children[prefix[i]]
+	}
+
+	if n == nil || n.rate == nil {
+		return Rate{}, false
+	}
+	return *n.rate, true
+}
+
+//The number of prefixes.
+func (d *RateDeck) Len() int {
+	return d.size
+}
+
+//Returns every rate sorted by prefix.
+func (d *RateDeck) Rates() Rates {
+	rates := make(Rates, 0, d.size)
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.rate != nil {
+			rates = append(rates, *n.rate)
+		}
+
+		keys := make([]int, 0, len(n.children))
+		for k := range n.children {
+			keys = append(keys, int(k))
+		}
+		sort.Ints(keys)
+		for _, k := range keys {
+			walk(n.children[byte(k)])
+		}
+	}
+	walk(&d.root)
+
+	return rates
+}
+
+//A rate deck as it was at a point in time, saved to notice when voip.ms changes its prices.
+type Snapshot struct {
+	Taken time.Time `json:"taken"`
+	Rates Rates     `json:"rates"` //Sorted by prefix.
+}
+
+func (d *RateDeck) Snapshot(taken time.Time) *Snapshot {
+	return &Snapshot{Taken: taken, Rates: d.Rates()}
+}
+
+func (s *Snapshot) Deck() *RateDeck {
+	return NewRateDeck(s.Rates)
+}
+
+func LoadSnapshot(path string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Snapshot) Save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), os.FileMode(0644))
+}
+
+//How a prefix's rate changed between two decks.
+type ChangeKind string
+
+const (
+	ChangeAdded     ChangeKind = "added"
+	ChangeRemoved   ChangeKind = "removed"
+	ChangeIncreased ChangeKind = "increased"
+	ChangeDecreased ChangeKind = "decreased"
+	ChangeIncrement ChangeKind = "increment" //Same per minute price, different billing increment.
+)
+
+type Change struct {
+	Kind   ChangeKind `json:"kind"`
+	Prefix string     `json:"prefix"`
+	Old    Rate       `json:"old"` //Zero when added.
+	New    Rate       `json:"new"` //Zero when removed.
+}
+
+//The change in per minute price. Positive for increases.
+func (c Change) Delta() v1.Money {
+	return c.New.PerMinute.Sub(c.Old.PerMinute)
+}
+
+//i.e. "1204 Canada - 204 Manitoba increased 0.0090 -> 0.0100".
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s %s added at %s", c.Prefix, c.New.Destination, c.New.PerMinute)
+	case ChangeRemoved:
+		return fmt.Sprintf("%s %s removed, was %s", c.Prefix, c.Old.Destination, c.Old.PerMinute)
+	case ChangeIncrement:
+		return fmt.Sprintf("%s %s increment %ds -> %ds", c.Prefix, c.New.Destination, c.Old.Increment, c.New.Increment)
+	}
+	return fmt.Sprintf("%s %s %s %s -> %s", c.Prefix, c.New.Destination, c.Kind, c.Old.PerMinute, c.New.PerMinute)
+}
+
+//Returns the prefixes added, removed or repriced going from old to new, sorted by prefix. Changes to a prefix's
+//destination name alone aren't reported.
+func Diff(old, new *RateDeck) []Change {
+	var changes []Change
+	o, n := old.Rates(), new.Rates()
+	for len(o) > 0 || len(n) > 0 {
+		switch {
+		case len(n) == 0 || (len(o) > 0 && o[0].Prefix < n[0].Prefix):
+			changes = append(changes, Change{Kind: ChangeRemoved, Prefix: o[0].Prefix, Old: o[0]})
+			o = o[1:]
+		case len(o) == 0 || n[0].Prefix < o[0].Prefix:
+			changes = append(changes, Change{Kind: ChangeAdded, Prefix: n[0].Prefix, New: n[0]})
+			n = n[1:]
+		default:
+			if kind, ok := compare(o[0], n[0]); ok {
+				changes = append(changes, Change{Kind: kind, Prefix: n[0].Prefix, Old: o[0], New: n[0]})
+			}
+			o, n = o[1:], n[1:]
+		}
+	}
+	return changes
+}
+
+func compare(old, new Rate) (ChangeKind, bool) {
+	switch new.PerMinute.Cmp(old.PerMinute) {
+	case 1:
+		return ChangeIncreased, true
+	case -1:
+		return ChangeDecreased, true
+	}
+	if new.Increment != old.Increment {
+		return ChangeIncrement, true
+	}
+	return "", false
+}
+
+//Diffs the decks of two snapshots.
+func DiffSnapshots(old, new *Snapshot) []Change {
+	return Diff(old.Deck(), new.Deck())
+}
@@ -0,0 +1,112 @@
+package rating
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateDeck_Match(t *testing.T) {
+
+	//setup
+	deck := NewRateDeck(testRates())
+	deck.Add(Rate{Prefix: "1204", Destination: "Canada - Manitoba", Increment: 6, PerMinute: v1.MustParseMoney("0.0080")})
+
+	//verify
+	require.Equal(t, 3, deck.Len())
+
+	rate, ok := deck.Match("12045551234")
+	require.True(t, ok)
+	require.Equal(t, "Canada - Manitoba", rate.Destination)
+
+	rate, ok = deck.Match("120")
+	require.True(t, ok)
+	require.Equal(t, "1", rate.Prefix)
+
+	rate, ok = deck.Match("4420")
+	require.True(t, ok)
+	require.Equal(t, "4420", rate.Prefix)
+
+	_, ok = deck.Match("442")
+	require.False(t, ok)
+	_, ok = deck.Match("")
+	require.False(t, ok)
+
+	_, ok = deck.Get("12")
+	require.False(t, ok)
+	rate, ok = deck.Get("1204")
+	require.True(t, ok)
+	require.Equal(t, "0.0080", rate.PerMinute.String())
+
+	for _, number := range []string{"12045551234", "14035551234", "442071234567", "33123456789"} {
+		expected, eok := testRates().Match(number)
+		actual, aok := deck.Match(number)
+		require.Equal(t, eok, aok, number)
+		require.Equal(t, expected.Prefix, actual.Prefix, number)
+	}
+}
+
+func TestRateDeck_Rates(t *testing.T) {
+	deck := NewRateDeck(Rates{{Prefix: "44"}, {Prefix: "1204"}, {Prefix: "1"}, {Prefix: "12"}, {Prefix: "4420"}})
+
+	var prefixes []string
+	for _, r := range deck.Rates() {
+		prefixes = append(prefixes, r.Prefix)
+	}
+	require.Equal(t, []string{"1", "12", "1204", "44", "4420"}, prefixes)
+}
+
+func TestSnapshot(t *testing.T) {
+
+	//setup
+	path := filepath.Join(t.TempDir(), "premium.json")
+	taken := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	old := NewRateDeck(testRates()).Snapshot(taken)
+
+	//execute
+	require.NoError(t, old.Save(path))
+	loaded, err := LoadSnapshot(path)
+
+	//verify
+	require.NoError(t, err)
+	require.True(t, taken.Equal(loaded.Taken))
+	require.Equal(t, old.Rates, loaded.Rates)
+	require.Equal(t, 3, loaded.Deck().Len())
+
+	_, err = LoadSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+
+	//setup
+	old := NewRateDeck(testRates())
+	new := NewRateDeck(Rates{
+		{Destination: "Canada", Prefix: "1", Increment: 6, PerMinute: v1.MustParseMoney("0.0100")},
+		{Destination: "Canada - Manitoba", Prefix: "1204", Increment: 6, PerMinute: v1.MustParseMoney("0.0110")},
+		{Destination: "France", Prefix: "33", Increment: 1, PerMinute: v1.MustParseMoney("0.0200")},
+	})
+
+	//execute
+	changes := DiffSnapshots(old.Snapshot(time.Time{}), new.Snapshot(time.Time{}))
+
+	//verify
+	require.Len(t, changes, 4)
+	require.Equal(t, ChangeIncrement, changes[0].Kind)
+	require.Equal(t, "1 Canada increment 60s -> 6s", changes[0].String())
+	require.Equal(t, ChangeIncreased, changes[1].Kind)
+	require.Equal(t, "0.0020", changes[1].Delta().String())
+	require.Equal(t, "1204 Canada - Manitoba increased 0.0090 -> 0.0110", changes[1].String())
+	require.Equal(t, ChangeAdded, changes[2].Kind)
+	require.Equal(t, "33 France added at 0.0200", changes[2].String())
+	require.Equal(t, ChangeRemoved, changes[3].Kind)
+	require.Equal(t, "4420", changes[3].Prefix)
+	require.Equal(t, "-0.0120", changes[3].Delta().String())
+
+	decreased := Diff(new, NewRateDeck(Rates{{Prefix: "1", Increment: 6, PerMinute: v1.MustParseMoney("0.0090")}}))
+	require.Equal(t, ChangeDecreased, decreased[0].Kind)
+	require.Len(t, Diff(old, old), 0)
+}
@@ -0,0 +1,244 @@
+//Package rating re-rates CDRs against voip.ms's published rates and reconciles the result with what voip.ms billed.
+//
+//	rates, err := cdrAPI.GetTerminationRates("2", "")
+//	deck := rating.NewRateDeck(rating.FromTerminationRates("Premium", rates))
+//	rec := rating.Reconcile(cdrs, deck, rating.Options{Tolerance: v1.MustParseMoney("0.0001")})
+//	for _, line := range rec.Discrepancies {
+//		fmt.Println(line.CDR.UniqueId, line.CDR.Total, line.Expected)
+//	}
+//
+//A CDR's expected cost is the per minute rate of the longest prefix matching its Destination, charged for its
+//duration rounded up to the rate's billing increment.
+package rating
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//A per minute price for numbers starting with Prefix.
+type Rate struct {
+	Route       string   `json:"route,omitempty"` //i.e. Premium or Value. Empty for package rates.
+	Prefix      string   `json:"prefix"`
+	Destination string   `json:"destination"` //voip.ms's name for the prefix, i.e. "Canada - 204 Manitoba".
+	Increment   int      `json:"increment"`   //Billing increment in seconds, i.e. 6 or 60.
+	PerMinute   v1.Money `json:"per_minute"`
+}
+
+//Rounds seconds up to a whole number of increments. An increment of 0 or less bills by the second.
+func (r Rate) Billed(seconds int) int {
+	if seconds <= 0 {
+		return 0
+	}
+
+	increment := r.Increment
+	if increment <= 0 {
+		increment = 1
+	}
+	return (seconds + increment - 1) / increment * increment
+}
+
+//The cost of a call lasting seconds.
+func (r Rate) Cost(seconds int) v1.Money {
+	return r.PerMinute.MulRatio(int64(r.Billed(seconds)), 60)
+}
+
+//Converts the rates from CDRAPI.GetRates at voip.ms's price to the reseller.
+func FromRates(rates []v1.Rate) Rates {
+	rs := make(Rates, len(rates))
+	for i, r := range rates {
+		rs[i] = Rate{Prefix: r.Prefix, Destination: r.Destination, Increment: r.RealIncrement.Int(), PerMinute: r.RealRate}
+	}
+	return rs
+}
+
+//Converts the rates from CDRAPI.GetRates at the reseller's price to the client.
+func FromClientRates(rates []v1.Rate) Rates {
+	rs := make(Rates, len(rates))
+	for i, r := range rates {
+		rs[i] = Rate{Prefix: r.Prefix, Destination: r.Destination, Increment: r.ClientIncrement.Int(), PerMinute: r.ClientRate}
+	}
+	return rs
+}
+
+//Converts the rates from CDRAPI.GetTerminationRates. route names them, i.e. Premium.
+func FromTerminationRates(route string, rates []v1.TerminationRate) Rates {
+	rs := make(Rates, len(rates))
+	for i, r := range rates {
+		rs[i] = Rate{Route: route, Prefix: r.Prefix, Destination: r.Destination, Increment: r.Increment.Int(), PerMinute: r.Rate}
+	}
+	return rs
+}
+
+//Finds the rate for a dialled number in digits only, i.e. 12045551234 or 442071234567.
+type Lookup interface {
+	Match(number string) (Rate, bool)
+}
+
+//A Lookup that scans every rate for the longest matching prefix. Fine for a handful of rates, use a RateDeck for a
+//whole deck.
+type Rates []Rate
+
+func (rs Rates) Match(number string) (Rate, bool) {
+	best, found := Rate{}, false
+	for _, r := range rs {
+		if strings.HasPrefix(number, r.Prefix) && (!found || len(r.Prefix) > len(best.Prefix)) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+//Turns a CDR's Destination into the digits rate prefixes are matched against. It drops everything but digits and
+//the 011 international prefix, so "011 44 20 7123 4567" becomes 442071234567.
+func Normalize(destination string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, destination)
+	return strings.TrimPrefix(digits, "011")
+}
+
+type Options struct {
+	Tolerance v1.Money              //Largest difference between the billed and expected cost that isn't a discrepancy.
+	Normalize func(string) string   //Turns CDR.Destination into digits to match. Defaults to Normalize.
+	Include   func(cdr v1.CDR) bool //Skips CDRs it returns false for, i.e. inbound calls. Defaults to all.
+}
+
+//One re-rated CDR.
+type Line struct {
+	CDR      v1.CDR   `json:"cdr"`
+	Rate     Rate     `json:"rate"`
+	Seconds  int      `json:"seconds"`  //CDR.Duration rounded up to the rate's increment.
+	Expected v1.Money `json:"expected"` //Rate.Cost of the call.
+	Diff     v1.Money `json:"diff"`     //CDR.Total less Expected. Positive when voip.ms billed more than expected.
+}
+
+//Billed and expected totals for a route or destination.
+type Summary struct {
+	Key           string   `json:"key"`
+	Calls         int      `json:"calls"`
+	Discrepancies int      `json:"discrepancies"`
+	Billed        v1.Money `json:"billed"`
+	Expected      v1.Money `json:"expected"`
+	Diff          v1.Money `json:"diff"`
+}
+
+func (s *Summary) add(line Line, discrepancy bool) {
+	s.Calls++
+	s.Billed = s.Billed.Add(line.CDR.Total)
+	s.Expected = s.Expected.Add(line.Expected)
+	s.Diff = s.Diff.Add(line.Diff)
+	if discrepancy {
+		s.Discrepancies++
+	}
+}
+
+type Reconciliation struct {
+	Discrepancies []Line    `json:"discrepancies"` //Lines whose Diff is beyond the tolerance, in the order added.
+	Unrated       []v1.CDR  `json:"unrated"`       //CDRs whose Destination matched no rate.
+	ByRoute       []Summary `json:"by_route"`      //Sorted by Key.
+	ByDestination []Summary `json:"by_destination"`
+	Total         Summary   `json:"total"`
+}
+
+//Re-rates CDRs added one at a time. It isn't safe for concurrent use.
+type Reconciler struct {
+	lookup       Lookup
+	opts         Options
+	rec          Reconciliation
+	routes       map[string]*Summary
+	destinations map[string]*Summary
+}
+
+func NewReconciler(lookup Lookup, opts Options) *Reconciler {
+	if opts.Normalize == nil {
+		opts.Normalize = Normalize
+	}
+	return &Reconciler{lookup: lookup, opts: opts, routes: map[string]*Summary{}, destinations: map[string]*Summary{}}
+}
+
+//Re-rates cdr without recording it.
+func (r *Reconciler) Rate(cdr v1.CDR) (Line, bool) {
+	rate, ok := r.lookup.Match(r.opts.Normalize(cdr.Destination))
+	if !ok {
+		return Line{CDR: cdr}, false
+	}
+
+	//Part seconds are billed as a whole second.
+	seconds := int((cdr.Duration + time.Second - 1) / time.Second)
+	line := Line{CDR: cdr, Rate: rate, Seconds: rate.Billed(seconds), Expected: rate.Cost(seconds)}
+	line.Diff = cdr.Total.Sub(line.Expected)
+	return line, true
+}
+
+func (r *Reconciler) Add(cdr v1.CDR) {
+	if r.opts.Include != nil && !r.opts.Include(cdr) {
+		return
+	}
+
+	line, ok := r.Rate(cdr)
+	if !ok {
+		r.rec.Unrated = append(r.rec.Unrated, cdr)
+		return
+	}
+
+	discrepancy := line.Diff.Abs().Cmp(r.opts.Tolerance) > 0
+	if discrepancy {
+		r.rec.Discrepancies = append(r.rec.Discrepancies, line)
+	}
+
+	r.rec.Total.add(line, discrepancy)
+	summary(r.routes, line.Rate.Route).add(line, discrepancy)
+	summary(r.destinations, line.Rate.Destination).add(line, discrepancy)
+}
+
+func summary(m map[string]*Summary, key string) *Summary {
+	s, ok := m[key]
+	if !ok {
+		s = &Summary{Key: key}
+		m[key] = s
+	}
+	return s
+}
+
+//Returns the reconciliation of everything added so far.
+func (r *Reconciler) Reconciliation() Reconciliation {
+	rec := r.rec
+	rec.Discrepancies = append([]Line(nil), rec.Discrepancies...)
+	rec.Unrated = append([]v1.CDR(nil), rec.Unrated...)
+	rec.ByRoute = summaries(r.routes)
+	rec.ByDestination = summaries(r.destinations)
+	return rec
+}
+
+func summaries(m map[string]*Summary) []Summary {
+	s := make([]Summary, 0, len(m))
+	for _, v := range m {
+		s = append(s, *v)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Key < s[j].Key })
+	return s
+}
+
+func Reconcile(cdrs []v1.CDR, lookup Lookup, opts Options) Reconciliation {
+	r := NewReconciler(lookup, opts)
+	for _, cdr := range cdrs {
+		r.Add(cdr)
+	}
+	return r.Reconciliation()
+}
+
+//Reconciles everything it returns. On error the reconciliation covers the CDRs read before it.
+func ReconcileIterator(it *v1.CDRIterator, lookup Lookup, opts Options) (Reconciliation, error) {
+	r := NewReconciler(lookup, opts)
+	for it.Next() {
+		r.Add(it.CDR())
+	}
+	return r.Reconciliation(), it.Err()
+}
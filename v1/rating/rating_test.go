@@ -0,0 +1,121 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func testRates() Rates {
+	return FromTerminationRates("Premium", []v1.TerminationRate{
+		{Destination: "Canada", Prefix: "1", Increment: 60, Rate: v1.MustParseMoney("0.0100")},
+		{Destination: "Canada - 204 Manitoba", Prefix: "1204", Increment: 6, Rate: v1.MustParseMoney("0.0090")},
+		{Destination: "United Kingdom - London", Prefix: "4420", Increment: 1, Rate: v1.MustParseMoney("0.0120")},
+	})
+}
+
+func call(id, destination string, seconds int, total string) v1.CDR {
+	return v1.CDR{
+		Destination: destination,
+		Disposition: "ANSWERED",
+		Duration:    time.Duration(seconds) * time.Second,
+		Seconds:     v1.FlexInt(seconds),
+		Total:       v1.MustParseMoney(total),
+		UniqueId:    id,
+	}
+}
+
+func TestRate_Cost(t *testing.T) {
+	rate := Rate{Increment: 6, PerMinute: v1.MustParseMoney("0.0090")}
+	require.Equal(t, 0, rate.Billed(0))
+	require.Equal(t, 6, rate.Billed(1))
+	require.Equal(t, 36, rate.Billed(31))
+	require.Equal(t, "0.0054", rate.Cost(31).String())
+	require.Equal(t, "0.0090", rate.Cost(60).String())
+
+	perSecond := Rate{PerMinute: v1.MustParseMoney("0.0120")}
+	require.Equal(t, 61, perSecond.Billed(61))
+	require.Equal(t, "0.0122", perSecond.Cost(61).String())
+}
+
+func TestRates_Match(t *testing.T) {
+	rates := testRates()
+
+	rate, ok := rates.Match("12045551234")
+	require.True(t, ok)
+	require.Equal(t, "1204", rate.Prefix)
+	require.Equal(t, "Premium", rate.Route)
+
+	rate, ok = rates.Match("14035551234")
+	require.True(t, ok)
+	require.Equal(t, "1", rate.Prefix)
+
+	_, ok = rates.Match("33123456789")
+	require.False(t, ok)
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "442071234567", Normalize("011 44 20 7123 4567"))
+	require.Equal(t, "12045551234", Normalize("+1 (204) 555-1234"))
+}
+
+func TestFromRates(t *testing.T) {
+	rates := []v1.Rate{{
+		Destination: "Canada - 204 Manitoba", Prefix: "1204",
+		ClientIncrement: 60, ClientRate: v1.MustParseMoney("0.015"),
+		RealIncrement: 6, RealRate: v1.MustParseMoney("0.0052"),
+	}}
+
+	require.Equal(t, Rates{{Prefix: "1204", Destination: "Canada - 204 Manitoba", Increment: 6, PerMinute: v1.MustParseMoney("0.0052")}}, FromRates(rates))
+	require.Equal(t, Rates{{Prefix: "1204", Destination: "Canada - 204 Manitoba", Increment: 60, PerMinute: v1.MustParseMoney("0.015")}}, FromClientRates(rates))
+}
+
+func TestReconcile(t *testing.T) {
+
+	//setup
+	cdrs := []v1.CDR{
+		call("1", "12045551234", 31, "0.0054"),
+		call("2", "12045551234", 31, "0.0090"),
+		call("3", "14035551234", 61, "0.0200"),
+		call("4", "0114420712345", 61, "0.0123"),
+		call("5", "33123456789", 60, "0.0500"),
+		call("6", "4035550000", 60, "0"),
+	}
+	cdrs[5].Description = "Inbound DID"
+
+	//execute
+	rec := Reconcile(cdrs, testRates(), Options{
+		Tolerance: v1.MustParseMoney("0.0001"),
+		Include:   func(cdr v1.CDR) bool { return cdr.Description != "Inbound DID" },
+	})
+
+	//verify
+	require.Len(t, rec.Discrepancies, 1)
+	line := rec.Discrepancies[0]
+	require.Equal(t, "2", line.CDR.UniqueId)
+	require.Equal(t, 36, line.Seconds)
+	require.Equal(t, "0.0054", line.Expected.String())
+	require.Equal(t, "0.0036", line.Diff.String())
+
+	require.Len(t, rec.Unrated, 1)
+	require.Equal(t, "5", rec.Unrated[0].UniqueId)
+
+	require.Equal(t, Summary{
+		Calls: 4, Discrepancies: 1,
+		Billed:   v1.MustParseMoney("0.0467"),
+		Expected: v1.MustParseMoney("0.0430"),
+		Diff:     v1.MustParseMoney("0.0037"),
+	}, rec.Total)
+	require.Len(t, rec.ByRoute, 1)
+	require.Equal(t, "Premium", rec.ByRoute[0].Key)
+	require.Equal(t, rec.Total.Diff, rec.ByRoute[0].Diff)
+
+	require.Len(t, rec.ByDestination, 3)
+	require.Equal(t, "Canada", rec.ByDestination[0].Key)
+	require.Equal(t, "Canada - 204 Manitoba", rec.ByDestination[1].Key)
+	require.Equal(t, 1, rec.ByDestination[1].Discrepancies)
+	require.Equal(t, "United Kingdom - London", rec.ByDestination[2].Key)
+	require.Equal(t, "0.0001", rec.ByDestination[2].Diff.String())
+}
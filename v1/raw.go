@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+type rawResponseKey struct{}
+
+//Calls any voip.ms method, i.e. ones this package doesn't wrap yet, and returns the response body undecoded. Requests
+//go through the same auth, retries, middleware and status checks as the typed functions, so a failed status comes back
+//as an *APIError.
+func (c *VOIPClient) Do(method string, params url.Values) (json.RawMessage, error) {
+	return c.DoContext(context.Background(), method, params)
+}
+
+func (c *VOIPClient) DoContext(ctx context.Context, method string, params url.Values) (json.RawMessage, error) {
+	var raw json.RawMessage
+	err := c.invoke(WithRawResponse(ctx, &raw), method, params, nil, !c.useGet || mutates(method))
+	return raw, err
+}
+
+//Returns a context that has typed functions store the raw response body in raw, i.e. to read fields the typed response
+//doesn't have yet. raw is also set when voip.ms answers with a failed status.
+//
+//	var raw json.RawMessage
+//	balance, err := general.GetBalanceContext(v1.WithRawResponse(ctx, &raw), true)
+func WithRawResponse(ctx context.Context, raw *json.RawMessage) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, raw)
+}
+
+func rawResponse(ctx context.Context) *json.RawMessage {
+	raw, _ := ctx.Value(rawResponseKey{}).(*json.RawMessage)
+	return raw
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVOIPClient_Do(t *testing.T) {
+
+	//setup
+	var method string
+	var params url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		params = formValues(r)
+		fmt.Fprintln(w, `{"status":"success","fax":{"id":"1"}}`)
+	}))
+	defer ts.Close()
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1")
+
+	//execute
+	raw, err := c.Do("setFaxFolder", url.Values{"name": {"Invoices"}})
+
+	//verify
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"success","fax":{"id":"1"}}`, string(raw))
+	require.Equal(t, "POST", method)
+	require.Equal(t, "setFaxFolder", params.Get("method"))
+	require.Equal(t, "Invoices", params.Get("name"))
+	require.Equal(t, "ApiPass1", params.Get("api_password"))
+}
+
+func TestVOIPClient_DoFailedStatus(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"invalid_folder"}`)
+	}))
+	defer ts.Close()
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1")
+
+	//execute
+	raw, err := c.Do("getFaxMessages", url.Values{"folder": {"nope"}})
+
+	//verify
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "getFaxMessages", apiErr.Method)
+	require.Equal(t, "invalid_folder", apiErr.Status)
+	require.True(t, errors.Is(err, ErrValidation))
+	require.JSONEq(t, `{"status":"invalid_folder"}`, string(raw))
+}
+
+func TestVOIPClient_WithRawResponse(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"success","ip":"10.0.0.1","new_field":"x"}`)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1").NewGeneralAPI()
+
+	var raw json.RawMessage
+
+	//execute
+	ip, err := api.GetIPContext(WithRawResponse(context.Background(), &raw))
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", ip)
+
+	rs := map[string]string{}
+	require.NoError(t, json.Unmarshal(raw, &rs))
+	require.Equal(t, "x", rs["new_field"])
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+//Controls how transient failures are retried. Transient failures are network errors, 5xx responses and throttling replies.
+type RetryPolicy struct {
+	MaxAttempts    int           //Total attempts, including the first. Values below 2 disable retries.
+	InitialBackoff time.Duration //Wait before the first retry.
+	MaxBackoff     time.Duration //Upper bound for any single wait.
+	Multiplier     float64       //Growth of the wait after each attempt.
+	Jitter         float64       //Fraction, 0 to 1, of each wait that is randomized.
+
+	//Decides which voip.ms methods may be retried at all. Defaults to methods that don't mutate anything, see
+	//Methods, as retrying something like orderDID could order twice.
+	Retryable func(method string) bool
+
+	//Called before waiting for each retry.
+	OnRetry func(RetryEvent)
+}
+
+type RetryEvent struct {
+	Method  string
+	Attempt int //The attempt that failed, starting at 1.
+	Err     error
+	Backoff time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+//Retries transient failures according to policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *VOIPClient) {
+		c.retry = &policy
+	}
+}
+
+//Returns how long to wait before retrying after attempt failed with err, or false when it shouldn't be retried.
+func (r *RetryPolicy) next(ctx context.Context, method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= r.MaxAttempts || ctx.Err() != nil || !isTransient(err) {
+		return 0, false
+	}
+
+	retryable := r.Retryable
+	if retryable == nil {
+		retryable = func(method string) bool { return !mutates(method) }
+	}
+	if !retryable(method) {
+		return 0, false
+	}
+
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(r.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		backoff = float64(r.MaxBackoff)
+	}
+
+	if r.Jitter > 0 {
+		backoff -= backoff * math.Min(r.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(backoff), true
+}
+
+func isTransient(err error) bool {
+	if errors.Is(err, ErrUnavailable) || errors.Is(err, ErrRateLimited) {
+		return true
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
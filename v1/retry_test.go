@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy(events *[]RetryEvent) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+		OnRetry: func(e RetryEvent) {
+			*events = append(*events, e)
+		},
+	}
+}
+
+func TestRetryPolicy_RetriesTransientReads(t *testing.T) {
+
+	//setup
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+	defer ts.Close()
+
+	var events []RetryEvent
+	api := NewVOIPClient(ts.URL, "", "", WithRetryPolicy(testRetryPolicy(&events))).NewGeneralAPI()
+
+	//execute
+	ip, err := api.GetIP()
+
+	//verify
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ip)
+	require.Equal(t, 3, calls)
+	require.Len(t, events, 2)
+	require.Equal(t, "getIP", events[0].Method)
+	require.Equal(t, 1, events[0].Attempt)
+	require.Equal(t, 2, events[1].Attempt)
+}
+
+func TestRetryPolicy_GivesUp(t *testing.T) {
+
+	//setup
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	var events []RetryEvent
+	api := NewVOIPClient(ts.URL, "", "", WithRetryPolicy(testRetryPolicy(&events))).NewGeneralAPI()
+
+	//execute
+	_, err := api.GetIP()
+
+	//verify
+	require.EqualError(t, err, "503 Service Unavailable")
+	require.Equal(t, 3, calls)
+	require.Len(t, events, 2)
+}
+
+func TestRetryPolicy_SkipsWritesAndAPIErrors(t *testing.T) {
+
+	//setup
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if formValues(r).Get("method") == "delSubAccount" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"status":"invalid_credentials"}`)
+	}))
+	defer ts.Close()
+
+	var events []RetryEvent
+	c := NewVOIPClient(ts.URL, "", "", WithRetryPolicy(testRetryPolicy(&events)))
+
+	//execute
+	errPost := c.NewAccountsAPI().DelSubAccount("1")
+	_, errGet := c.NewGeneralAPI().GetIP()
+
+	//verify
+	require.Error(t, errPost)
+	require.EqualError(t, errGet, "invalid_credentials")
+	require.Equal(t, 2, calls)
+	require.Len(t, events, 0)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+
+	//setup
+	p := RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	err := newAPIError("getIP", "", http.StatusBadGateway)
+
+	//execute & verify
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		d, ok := p.next(context.Background(), "getIP", attempt+1, err)
+		require.True(t, ok)
+		require.Equal(t, expected, d)
+	}
+
+	p.Jitter = 0.5
+	d, ok := p.next(context.Background(), "getIP", 1, err)
+	require.True(t, ok)
+	require.True(t, d > 500*time.Millisecond && d <= time.Second)
+
+	_, ok = p.next(context.Background(), "orderDID", 1, err)
+	require.False(t, ok)
+}
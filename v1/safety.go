@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+//Matched with errors.Is against the *RefusedError a call returns when the client's Mode stops it being sent.
+var (
+	ErrDryRun   = errors.New("voipms: refused in dry run mode")
+	ErrReadOnly = errors.New("voipms: refused in read only mode")
+)
+
+//Limits what a client may change on the account. See WithDryRun and WithReadOnly.
+type Mode int
+
+const (
+	ModeLive     Mode = iota //Everything is sent as is. The default.
+	ModeDryRun               //Methods with a test parameter are sent with test=true. Other mutating methods are refused.
+	ModeReadOnly             //Only methods that don't mutate anything are sent.
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeDryRun:
+		return "dry run"
+	case ModeReadOnly:
+		return "read only"
+	}
+	return "live"
+}
+
+//Returned instead of sending a mutating call the client's Mode doesn't allow. Nothing was sent to voip.ms.
+type RefusedError struct {
+	Method string
+	Params url.Values //What would have been sent, with credentials and secrets Redacted.
+	Mode   Mode
+}
+
+func (e *RefusedError) Error() string {
+	return fmt.Sprintf("voipms: %s refused in %s mode", e.Method, e.Mode)
+}
+
+func (e *RefusedError) Unwrap() error {
+	if e.Mode == ModeReadOnly {
+		return ErrReadOnly
+	}
+	return ErrDryRun
+}
+
+//Sends test=true on every method that supports it and refuses every other mutating method. Reads are unaffected. Use
+//it to exercise provisioning code against a real account. See MethodInfo for which methods are which.
+func WithDryRun() Option {
+	return func(c *VOIPClient) {
+		c.mode = ModeDryRun
+	}
+}
+
+//Refuses every method that mutates something, test ones included. Use it when handing credentials to reporting jobs.
+func WithReadOnly() Option {
+	return func(c *VOIPClient) {
+		c.mode = ModeReadOnly
+	}
+}
+
+func (c *VOIPClient) Mode() Mode {
+	return c.mode
+}
+
+//Wraps next to apply the client's Mode. It sits inside the user middleware so refused calls are still seen by them.
+func (c *VOIPClient) guard(next Handler) Handler {
+	return func(ctx context.Context, inv *Invocation) error {
+		if !mutates(inv.Method) {
+			return next(ctx, inv)
+		}
+
+		if c.mode == ModeDryRun && supportsTest(inv.Method) {
+			inv.params.Set("test", "true")
+			inv.Params.Set("test", "true")
+			return next(ctx, inv)
+		}
+
+		return &RefusedError{Method: inv.Method, Params: inv.Params, Mode: c.mode}
+	}
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordingServer(t *testing.T, sent *[]string, tests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*sent = append(*sent, r.Form.Get("method"))
+		*tests = append(*tests, r.Form.Get("test"))
+		fmt.Fprintln(w, `{"status":"success","ip":"127.0.0.1"}`)
+	}))
+}
+
+func TestWithDryRun(t *testing.T) {
+
+	//setup
+	var sent, tests []string
+	ts := newRecordingServer(t, &sent, &tests)
+	defer ts.Close()
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithDryRun())
+
+	//execute
+	errCharge := c.NewClientsAPI().AddCharge("1", "Monthly", MustParseMoney("5"), false)
+	errCancel := c.NewDIDsAPI().CancelDID("5555551234", "", false, false)
+	_, errIP := c.NewGeneralAPI().GetIP()
+	errSet := c.NewClientsAPI().SetClient(&Client{Client: "1", Email: "jane@example.com", Password: "Secret1"})
+	_, errDo := c.Do("sendSMS", nil)
+
+	//verify
+	require.Equal(t, ModeDryRun, c.Mode())
+	require.NoError(t, errCharge)
+	require.NoError(t, errCancel)
+	require.NoError(t, errIP)
+	require.Equal(t, []string{"addCharge", "cancelDID", "getIP"}, sent)
+	require.Equal(t, []string{"true", "true", ""}, tests)
+
+	refused := &RefusedError{}
+	require.True(t, errors.As(errSet, &refused))
+	require.True(t, errors.Is(errSet, ErrDryRun))
+	require.False(t, errors.Is(errSet, ErrReadOnly))
+	require.Equal(t, "voipms: setClient refused in dry run mode", errSet.Error())
+	require.Equal(t, "setClient", refused.Method)
+	require.Equal(t, "jane@example.com", refused.Params.Get("email"))
+	require.Equal(t, Redacted, refused.Params.Get("password"))
+	require.True(t, errors.Is(errDo, ErrDryRun))
+}
+
+func TestWithReadOnly(t *testing.T) {
+
+	//setup
+	var sent, tests []string
+	ts := newRecordingServer(t, &sent, &tests)
+	defer ts.Close()
+
+	var seen []error
+	observe := func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) error {
+			err := next(ctx, inv)
+			seen = append(seen, err)
+			return err
+		}
+	}
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithReadOnly(), WithMiddleware(observe))
+
+	//execute
+	errCharge := c.NewClientsAPI().AddCharge("1", "Monthly", MustParseMoney("5"), true)
+	_, errIP := c.NewGeneralAPI().GetIP()
+	_, errE911 := c.Do("e911Info", nil)
+
+	//verify
+	require.True(t, errors.Is(errCharge, ErrReadOnly))
+	require.Equal(t, "voipms: addCharge refused in read only mode", errCharge.Error())
+	require.NoError(t, errIP)
+	require.NoError(t, errE911)
+	require.Len(t, seen, 3)
+	require.Equal(t, errCharge, seen[0])
+	require.Equal(t, []string{"getIP", "e911Info"}, sent)
+}
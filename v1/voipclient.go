@@ -1,19 +1,15 @@
 package v1
 
 import (
+	"context"
 	"net/http"
-	"log"
 	"encoding/json"
-	"errors"
 	"mime/multipart"
-	"reflect"
 	"strings"
 	"net/http/httputil"
-	"bytes"
+	"time"
 	"net/url"
 	"io/ioutil"
-	"fmt"
-	"encoding"
 )
 
 type VOIPClient struct {
@@ -21,6 +17,19 @@ type VOIPClient struct {
 	Username string
 	Password string
 	Debug    bool
+
+	client  *http.Client
+	retry   *RetryPolicy
+	breaker *CircuitBreaker
+	limiter Limiter
+	logger  Logger
+	rawDump bool
+	useGet  bool
+	mode    Mode
+
+	middleware []Middleware
+	cache      *Cache
+	audit      AuditSink
 }
 
 type StatusResp interface {
@@ -41,128 +50,279 @@ type StringValueDescription struct {
 }
 
 type NumberValueDescription struct {
-	Value       json.Number `json:"value"`
+	Value       FlexInt `json:"value"`
 	Description string `json:"description"`
 }
 
-func NewVOIPClient(url, username, password string, debug bool) *VOIPClient {
-	return &VOIPClient{url, username, password, debug}
+//Options are applied in order after the defaults, see options.go.
+func NewVOIPClient(url, username, password string, options ...Option) *VOIPClient {
+	c := &VOIPClient{
+		URL:      url,
+		Username: username,
+		Password: password,
+		client:   newDefaultHTTPClient(),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+//Returns the *http.Client requests are sent with. Clients built as struct literals share a default one.
+func (c *VOIPClient) HTTPClient() *http.Client {
+	if c.client == nil {
+		return sharedHTTPClient
+	}
+	return c.client
 }
 
 func (c *VOIPClient) Call(req *http.Request, respStruct interface{}) (*http.Response, error) {
+	return c.CallContext(req.Context(), req, respStruct)
+}
+
+//Performs req bound to ctx so cancellation and deadlines reach the underlying HTTP request.
+func (c *VOIPClient) CallContext(ctx context.Context, req *http.Request, respStruct interface{}) (*http.Response, error) {
+	resp, body, err := c.roundTrip(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	//Error pages aren't JSON. The status code is left for the caller to check.
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	if err := json.Unmarshal(body, respStruct); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+//Sends req and reads the whole response body.
+func (c *VOIPClient) roundTrip(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	req = req.WithContext(ctx)
 
 	if c.Debug {
-		out, _ := httputil.DumpRequest(req, true)
-		log.Println(string(out))
+		c.logRequest(req)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.HTTPClient().Do(req)
 	if err != nil {
-		return resp, err
+		return resp, nil, err
 	}
 	defer resp.Body.Close()
 
-	body := resp.Body
-	if c.Debug {
-		log.Println("Response: ", resp)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		b, err := ioutil.ReadAll(body)
-		if err != nil {
-			return nil, err
+	if c.Debug {
+		logged := body
+		if !c.rawDump {
+			logged = RedactJSON(body)
 		}
+		c.log("voipms response", "status", resp.Status, "body", string(logged))
+	}
 
-		log.Println(string(b))
+	return resp, body, nil
+}
 
-		body = ioutil.NopCloser(bytes.NewReader(b))
-	}
+//params is either url.Values or a request struct for EncodeValues.
+func (c *VOIPClient) Get(method string, params interface{}, entity interface{}) error {
+	return c.GetContext(context.Background(), method, params, entity)
+}
 
-	decoder := json.NewDecoder(body)
-	if err := decoder.Decode(respStruct); err != nil {
-		return nil, err
+func (c *VOIPClient) GetContext(ctx context.Context, method string, params interface{}, entity interface{}) error {
+	values, err := EncodeValues(params)
+	if err != nil {
+		return err
 	}
 
-	return resp, err
+	return c.invoke(ctx, method, values, entity, !c.useGet || mutates(method))
 }
 
-func (c *VOIPClient) Get(method string, values url.Values, entity interface{}) error {
+func (c *VOIPClient) Post(method string, entity interface{}, respStruct interface{}) error {
+	return c.PostContext(context.Background(), method, entity, respStruct)
+}
 
-	u, err := url.Parse(c.URL)
+func (c *VOIPClient) PostContext(ctx context.Context, method string, entity interface{}, respStruct interface{}) error {
+	values, err := EncodeValues(entity)
 	if err != nil {
 		return err
 	}
 
-	values.Add("api_username", c.Username)
-	values.Add("api_password", c.Password)
-	values.Add("method", method)
+	return c.invoke(ctx, method, values, respStruct, true)
+}
 
-	u.RawQuery = values.Encode()
+//Runs method through the middleware chain and decodes the response into respStruct, if there is one.
+func (c *VOIPClient) invoke(ctx context.Context, method string, values url.Values, respStruct interface{}, post bool) error {
+	params := url.Values{}
+	for k, v := range values {
+		params[k] = v
+	}
+	params.Set("method", method)
 
-	req, err := http.NewRequest("GET", u.String(), nil)
-	req.Header.Set("Content-Type", "application/json")
-	if err != nil {
-		panic(err)
+	inv := &Invocation{
+		Method: method,
+		Params: RedactValues(params),
+		params: params,
+		post:   post,
 	}
 
-	resp, err := c.Call(req, entity)
-	if err != nil {
-		return err
+	handler := c.send
+	if c.cache != nil {
+		handler = c.cache.wrap(c.Username, handler)
+	}
+	if c.mode != ModeLive {
+		handler = c.guard(handler)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	if c.audit != nil {
+		handler = c.auditor(handler)
 	}
 
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
+	err := handler(ctx, inv)
+
+	if raw := rawResponse(ctx); raw != nil && len(inv.Response) > 0 {
+		*raw = append(json.RawMessage(nil), inv.Response...)
 	}
 
-	s, ok := entity.(StatusResp)
-	if ok && s.GetStatus() != "success" {
-		return errors.New(s.GetStatus())
+	if err != nil || respStruct == nil {
+		return err
 	}
 
-	return nil
+	return json.Unmarshal(inv.Response, respStruct)
 }
 
-func (c *VOIPClient) Post(method string, entity interface{}, respStruct interface{}) error {
+//The innermost Handler. Sends inv to voip.ms, retrying as the retry policy and circuit breaker allow.
+func (c *VOIPClient) send(ctx context.Context, inv *Invocation) error {
+	start := time.Now()
+	defer func() {
+		inv.Latency = time.Since(start)
+	}()
+
+	for attempt := 1; ; attempt++ {
+		if c.breaker != nil {
+			if err := c.breaker.Allow(); err != nil {
+				return err
+			}
+		}
 
-	bodyBuf := &bytes.Buffer{}
-	bodyWriter := multipart.NewWriter(bodyBuf)
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, inv.Method); err != nil {
+				if c.breaker != nil {
+					c.breaker.release()
+				}
+				return err
+			}
+		}
 
-	bodyWriter.WriteField("api_username", c.Username)
-	bodyWriter.WriteField("api_password", c.Password)
-	bodyWriter.WriteField("method", method)
+		err := c.attempt(ctx, inv)
 
-	if err := c.WriteStruct(bodyWriter, entity); err != nil {
+		//Timeouts of the HTTP client count, only the caller giving up doesn't.
+		if c.breaker != nil && ctx.Err() != nil {
+			c.breaker.release()
+		} else if c.breaker != nil {
+			c.breaker.Record(err)
+		}
+
+		if err == nil || c.retry == nil {
+			return err
+		}
+
+		backoff, ok := c.retry.next(ctx, inv.Method, attempt, err)
+		if !ok {
+			return err
+		}
+
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(RetryEvent{inv.Method, attempt, err, backoff})
+		}
+
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+//Adds the credentials to the parameters and sends them either as a form encoded POST body or in the query string.
+func (c *VOIPClient) attempt(ctx context.Context, inv *Invocation) error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
 		return err
 	}
 
-	contentType := bodyWriter.FormDataContentType()
-	bodyWriter.Close()
+	params := url.Values{}
+	for k, v := range inv.params {
+		params[k] = v
+	}
+	params.Set("api_username", c.Username)
+	params.Set("api_password", c.Password)
+
+	var req *http.Request
+	if inv.post {
+		req, err = http.NewRequestWithContext(ctx, "POST", u.String(), strings.NewReader(params.Encode()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		u.RawQuery = params.Encode()
+		req, err = http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-	req, err := http.NewRequest("POST", c.URL, bodyBuf)
-	req.Header.Set("Content-Type", contentType)
-	resp, err := c.Call(req, respStruct)
+	resp, body, err := c.roundTrip(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
+	inv.HTTPStatus = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(inv.Method, "", resp.StatusCode)
 	}
 
-	s, ok := respStruct.(StatusResp)
-	if ok && s.GetStatus() != "success" {
-		return errors.New(s.GetStatus())
+	rs := &BaseResp{}
+	if err := json.Unmarshal(body, rs); err != nil {
+		return err
+	}
+
+	inv.Status = rs.Status
+	inv.Response = body
+	if rs.Status != "success" {
+		return newAPIError(inv.Method, rs.Status, resp.StatusCode)
 	}
 
 	return nil
 }
 
+func (c *VOIPClient) logRequest(req *http.Request) {
+	if c.rawDump {
+		out, _ := httputil.DumpRequest(req, true)
+		c.log("voipms request", "dump", string(out))
+		return
+	}
+
+	params := RedactValues(RequestParams(req))
+	c.log("voipms request", "http_method", req.Method, "method", params.Get("method"), "params", params)
+}
+
 // Function to simplify calls that only take a single string argument (i.e. an ID) and only return an error on failure, i.e. status != "success"
-func (c *VOIPClient) simpleCall(method, argName, argValue string) error {
+func (c *VOIPClient) simpleCallContext(ctx context.Context, method, argName, argValue string) error {
 	values := url.Values{}
 	values.Add(argName, argValue)
 
 	rs := &BaseResp{}
-	return c.Get(method, values, rs)
+	return c.GetContext(ctx, method, values, rs)
 }
 
 func (c *VOIPClient) NewGeneralAPI() *GeneralAPI {
@@ -196,68 +356,7 @@ func (c *VOIPClient) NewVoicemailAPI() *VoicemailAPI {
 	panic("NOT IMPLEMENTED YET!")
 }
 
+//Writes the fields of iface to writer as EncodeValues would encode them.
 func (c *VOIPClient) WriteStruct(writer *multipart.Writer, iface interface{}) error {
-	val := reflect.Indirect(reflect.ValueOf(iface))
-
-	FieldLoop:
-	for i := 0; i < val.NumField(); i++ {
-
-		structField := val.Type().Field(i)
-		jsonTag := structField.Tag.Get("json")
-
-		name := strings.TrimSuffix(jsonTag, ",omitempty")
-		if name == "" {
-			name = strings.ToLower(structField.Name)
-		}
-
-		value := ""
-		omitEmpty := strings.Contains(jsonTag, ",omitempty")
-		o := val.Field(i).Interface()
-
-		t := structField.Type
-		switch t.Kind() {
-		case reflect.Struct:
-			tm, ok := o.(encoding.TextMarshaler)
-			if ok {
-				text, err := tm.MarshalText()
-				if err != nil {
-					return err
-				}
-
-				value = string(text)
-				break
-			}
-
-			//Write nested structs out and continue to the next field.
-			//It is possible for nested struct field names to collide with top level field names with how this works.
-			if err := c.WriteStruct(writer, o); err != nil {
-				return err
-			}
-			continue FieldLoop
-		case reflect.Bool: //Only write booleans if they are true. False is assumed if the field is not present in the request.
-			b := o.(bool)
-			if b {
-				value = "true"
-			} else {
-				continue FieldLoop
-			}
-		case reflect.String: //Only write non-empty strings if they allow it with their JSON tags.
-			value = fmt.Sprintf("%v", o) //json.Number and others also land here. Doing a o.(string) panics when that happens.
-			if value == "" && omitEmpty {
-				continue FieldLoop
-			}
-		default:
-			value = fmt.Sprintf("%v", o)
-			if c.Debug {
-				log.Printf("Type: %s being written as value: %s for field: %s via WriteStruct:", t, value, name)
-			}
-		}
-
-		if err := writer.WriteField(name, value); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return encodeFields(iface, writer.WriteField)
 }
-
@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+//Parameters sent with r, from the query string or the form body.
+func formValues(r *http.Request) url.Values {
+	r.ParseForm()
+	return r.Form
+}
+
+func TestVOIPClient_PostForm(t *testing.T) {
+
+	//setup
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		require.Equal(t, "", r.URL.RawQuery)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "me@example.com", r.PostForm.Get("api_username"))
+		require.Equal(t, "ApiPass1", r.PostForm.Get("api_password"))
+		require.Equal(t, "getCountries", r.PostForm.Get("method"))
+		require.Equal(t, "CA", r.PostForm.Get("country"))
+		fmt.Fprintln(w, `{"status":"success","countries":[]}`)
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1").NewGeneralAPI()
+
+	//execute
+	_, err := api.GetCountries("CA")
+
+	//verify
+	require.NoError(t, err)
+}
+
+func TestVOIPClient_WithGetRequests(t *testing.T) {
+
+	//setup
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "GET" {
+			require.Contains(t, []string{"getCountries", "e911Info"}, r.URL.Query().Get("method"))
+			require.Equal(t, "ApiPass1", r.URL.Query().Get("api_password"))
+		} else {
+			require.Equal(t, "", r.URL.RawQuery)
+			require.NoError(t, r.ParseForm())
+			require.Contains(t, []string{"delSubAccount", "delCallback"}, r.PostForm.Get("method"))
+		}
+		fmt.Fprintln(w, `{"status":"success","countries":[]}`)
+	}))
+	defer ts.Close()
+
+	c := NewVOIPClient(ts.URL, "me@example.com", "ApiPass1", WithGetRequests())
+
+	//execute
+	_, errGet := c.NewGeneralAPI().GetCountries("CA")
+	errPost := c.NewAccountsAPI().DelSubAccount("12345")
+	errDel := c.NewDIDsAPI().DelCallback("1234")
+	_, errE911 := c.Do("e911Info", url.Values{"did": {"5555551234"}}) //a read without a get prefix
+
+	//verify
+	require.NoError(t, errGet)
+	require.NoError(t, errPost)
+	require.NoError(t, errDel)
+	require.NoError(t, errE911)
+	require.Equal(t, []string{"GET", "POST", "POST", "GET"}, methods)
+}
+
+func TestVOIPClient_GetContext_Deadline(t *testing.T) {
+
+	//setup
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	api := NewVOIPClient(ts.URL, "", "").NewGeneralAPI()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	//execute
+	balance, err := api.GetBalanceContext(ctx, false)
+
+	//verify
+	require.Nil(t, balance)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestVOIPClient_PostContext_Canceled(t *testing.T) {
+
+	//setup
+	called := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer ts.Close()
+
+	api := NewVOIPClient(ts.URL, "", "").NewAccountsAPI()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//execute
+	err := api.DelSubAccountContext(ctx, "12345")
+
+	//verify
+	require.True(t, errors.Is(err, context.Canceled))
+	require.False(t, called)
+}
@@ -0,0 +1,345 @@
+package voipmstest
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+type handler func(s *Server, params url.Values) map[string]interface{}
+
+//Every method the fake understands. Anything else is answered with invalid_method.
+var handlers = map[string]handler{
+	"getBalance":         getBalance,
+	"getIP":              getIP,
+	"createSubAccount":   createSubAccount,
+	"getSubAccounts":     getSubAccounts,
+	"setSubAccount":      setSubAccount,
+	"delSubAccount":      delSubAccount,
+	"orderDID":           orderDID,
+	"getDIDsInfo":        getDIDsInfo,
+	"cancelDID":          cancelDID,
+	"sendSMS":            sendSMS,
+	"getSMS":             getSMS,
+	"deleteSMS":          deleteSMS,
+	"signupClient":       signupClient,
+	"getClients":         getClients,
+	"addCharge":          addCharge,
+	"addPayment":         addPayment,
+	"getCharges":         getCharges,
+	"getDeposits":        getDeposits,
+	"getResellerBalance": getResellerBalance,
+}
+
+func status(s string) map[string]interface{} {
+	return map[string]interface{}{"status": s}
+}
+
+func isTrue(v string) bool {
+	return v == "true" || v == "1" || v == "yes"
+}
+
+func getBalance(s *Server, params url.Values) map[string]interface{} {
+	balance := map[string]interface{}{
+		"current_balance": s.balance,
+	}
+	if isTrue(params.Get("advanced")) {
+		balance["spent_total"] = s.spent
+	}
+	return map[string]interface{}{"balance": balance}
+}
+
+func getIP(s *Server, params url.Values) map[string]interface{} {
+	return map[string]interface{}{"ip": "127.0.0.1"}
+}
+
+func createSubAccount(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "username", "protocol", "auth_type", "device_type"); m != "" {
+		return status(m)
+	}
+
+	account := s.AccountId + "_" + params.Get("username")
+	if len(filter(s.accounts, map[string]string{"account": account})) > 0 {
+		return status("used_username")
+	}
+
+	id := s.id()
+	r := newRecord(params)
+	r["id"] = id
+	r["account"] = account
+	s.accounts = append(s.accounts, r)
+
+	n, _ := strconv.Atoi(id)
+	return map[string]interface{}{"id": n, "account": account}
+}
+
+func getSubAccounts(s *Server, params url.Values) map[string]interface{} {
+	accounts := filter(s.accounts, map[string]string{"account": params.Get("account")})
+	if params.Get("account") != "" && len(accounts) == 0 {
+		return status("invalid_account")
+	}
+	return map[string]interface{}{"accounts": accounts}
+}
+
+func setSubAccount(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "id"); m != "" {
+		return status(m)
+	}
+
+	matched := filter(s.accounts, map[string]string{"id": params.Get("id")})
+	if len(matched) == 0 {
+		return status("invalid_id")
+	}
+
+	for k, v := range newRecord(params) {
+		if k != "account" {
+			matched[0][k] = v
+		}
+	}
+	return map[string]interface{}{}
+}
+
+func delSubAccount(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "id"); m != "" {
+		return status(m)
+	}
+
+	var ok bool
+	if s.accounts, ok = remove(s.accounts, "id", params.Get("id")); !ok {
+		return status("invalid_id")
+	}
+	return map[string]interface{}{}
+}
+
+func orderDID(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "did", "routing", "pop", "dialtime", "cnam", "billing_type"); m != "" {
+		return status(m)
+	}
+
+	if len(filter(s.dids, map[string]string{"did": params.Get("did")})) > 0 {
+		return status("invalid_did")
+	}
+
+	if isTrue(params.Get("test")) {
+		return map[string]interface{}{}
+	}
+
+	if !s.charge(s.Prices.DIDSetup.Add(s.Prices.DIDMonthly)) {
+		return status("non_sufficient_funds")
+	}
+
+	now := s.Now()
+	r := newRecord(params)
+	r["description"] = params.Get("note")
+	r["order_date"] = now.Format("2006-01-02 15:04:05")
+	r["next_billing"] = now.AddDate(0, 1, 0).Format("2006-01-02")
+	s.dids = append(s.dids, r)
+
+	return map[string]interface{}{}
+}
+
+//DIDs aren't ordered for a client here, so client is ignored and every DID is returned.
+func getDIDsInfo(s *Server, params url.Values) map[string]interface{} {
+	dids := filter(s.dids, map[string]string{"did": params.Get("did")})
+	if params.Get("did") != "" && len(dids) == 0 {
+		return status("invalid_did")
+	}
+	return map[string]interface{}{"dids": dids}
+}
+
+func cancelDID(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "did"); m != "" {
+		return status(m)
+	}
+
+	if len(filter(s.dids, map[string]string{"did": params.Get("did")})) == 0 {
+		return status("invalid_did")
+	}
+
+	if !isTrue(params.Get("test")) {
+		s.dids, _ = remove(s.dids, "did", params.Get("did"))
+	}
+	return map[string]interface{}{}
+}
+
+func sendSMS(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "did", "dst", "message"); m != "" {
+		return status(m)
+	}
+
+	if len(filter(s.dids, map[string]string{"did": params.Get("did")})) == 0 {
+		return status("invalid_did")
+	}
+
+	if len(params.Get("message")) > 160 {
+		return status("invalid_message")
+	}
+
+	if !s.charge(s.Prices.SMS) {
+		return status("non_sufficient_funds")
+	}
+
+	id := s.id()
+	s.sms = append(s.sms, Record{
+		"id":      id,
+		"date":    s.Now().Format("2006-01-02 15:04:05"),
+		"type":    "0", //sent, 1 is received.
+		"did":     params.Get("did"),
+		"contact": params.Get("dst"),
+		"message": params.Get("message"),
+	})
+
+	n, _ := strconv.Atoi(id)
+	return map[string]interface{}{"sms": n}
+}
+
+func getSMS(s *Server, params url.Values) map[string]interface{} {
+	sms := filter(s.sms, map[string]string{
+		"id":      params.Get("sms"),
+		"did":     params.Get("did"),
+		"contact": params.Get("contact"),
+		"type":    params.Get("type"),
+	})
+	if len(sms) == 0 {
+		return status("no_sms")
+	}
+	return map[string]interface{}{"sms": sms}
+}
+
+func deleteSMS(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "id"); m != "" {
+		return status(m)
+	}
+
+	var ok bool
+	if s.sms, ok = remove(s.sms, "id", params.Get("id")); !ok {
+		return status("invalid_id")
+	}
+	return map[string]interface{}{}
+}
+
+func signupClient(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "firstname", "lastname", "email", "confirm_email", "password", "confirm_password"); m != "" {
+		return status(m)
+	}
+
+	if params.Get("email") != params.Get("confirm_email") {
+		return status("invalid_confirm_email")
+	}
+	if params.Get("password") != params.Get("confirm_password") {
+		return status("invalid_confirm_password")
+	}
+	if len(filter(s.clients, map[string]string{"email": params.Get("email")})) > 0 {
+		return status("used_email")
+	}
+
+	r := newRecord(params)
+	delete(r, "confirm_email")
+	delete(r, "confirm_password")
+	delete(r, "activate")
+	r["client"] = s.id()
+	r["balance"] = v1.Money{}
+	s.clients = append(s.clients, r)
+
+	return map[string]interface{}{"client": r["client"]}
+}
+
+func getClients(s *Server, params url.Values) map[string]interface{} {
+	clients := filter(s.clients, map[string]string{"client": params.Get("client")})
+	if params.Get("client") != "" && len(clients) == 0 {
+		return status("invalid_client")
+	}
+
+	out := []Record{}
+	for _, c := range clients {
+		r := Record{}
+		for k, v := range c {
+			if k != "balance" {
+				r[k] = v
+			}
+		}
+		out = append(out, r)
+	}
+	return map[string]interface{}{"clients": out}
+}
+
+func addCharge(s *Server, params url.Values) map[string]interface{} {
+	return clientTransaction(s, params, "charge", -1)
+}
+
+func addPayment(s *Server, params url.Values) map[string]interface{} {
+	return clientTransaction(s, params, "payment", 1)
+}
+
+//Records a charge or payment against a client's balance. Charges are stored as negative amounts like voip.ms does.
+func clientTransaction(s *Server, params url.Values, name string, sign int64) map[string]interface{} {
+	if m := missing(params, "client", name); m != "" {
+		return status(m)
+	}
+
+	clients := filter(s.clients, map[string]string{"client": params.Get("client")})
+	if len(clients) == 0 {
+		return status("invalid_client")
+	}
+
+	amount, err := v1.ParseMoney(params.Get(name))
+	if err != nil || amount.Sign() <= 0 {
+		return status("invalid_" + name)
+	}
+
+	if isTrue(params.Get("test")) {
+		return map[string]interface{}{}
+	}
+
+	client := clients[0]
+	amount = amount.Mul(sign)
+	client["balance"] = client["balance"].(v1.Money).Add(amount)
+
+	key := client["client"].(string) + "/" + name
+	s.charges[key] = append(s.charges[key], Record{
+		"id":          s.id(),
+		"date":        s.Now().Format("2006-01-02 15:04:05"),
+		"amount":      amount,
+		"description": params.Get("description"),
+	})
+
+	return map[string]interface{}{}
+}
+
+func getCharges(s *Server, params url.Values) map[string]interface{} {
+	return clientTransactions(s, params, "charge", "charges")
+}
+
+func getDeposits(s *Server, params url.Values) map[string]interface{} {
+	return clientTransactions(s, params, "payment", "deposits")
+}
+
+func clientTransactions(s *Server, params url.Values, name, field string) map[string]interface{} {
+	if m := missing(params, "client"); m != "" {
+		return status(m)
+	}
+
+	if len(filter(s.clients, map[string]string{"client": params.Get("client")})) == 0 {
+		return status("invalid_client")
+	}
+
+	records := s.charges[params.Get("client")+"/"+name]
+	if records == nil {
+		records = []Record{}
+	}
+	return map[string]interface{}{field: records}
+}
+
+func getResellerBalance(s *Server, params url.Values) map[string]interface{} {
+	if m := missing(params, "client"); m != "" {
+		return status(m)
+	}
+
+	clients := filter(s.clients, map[string]string{"client": params.Get("client")})
+	if len(clients) == 0 {
+		return status("invalid_client")
+	}
+
+	return map[string]interface{}{"balance": map[string]interface{}{"current_balance": clients[0]["balance"]}}
+}
@@ -0,0 +1,256 @@
+//Package voipmstest runs an in-memory fake of voip.ms's rest.php for end to end tests. It keeps real state between
+//calls: sub accounts created with createSubAccount show up in getSubAccounts, orderDID adds to getDIDsInfo and
+//charges the balance, sent SMS show up in getSMS and so on. Failures can be scripted per method.
+//
+//	s := voipmstest.NewServer()
+//	defer s.Close()
+//	c := s.Client()
+//	s.FailNext("orderDID", "non_sufficient_funds")
+package voipmstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stancarney/govoipms/v1"
+)
+
+//Prices charged to the account balance.
+type Prices struct {
+	DIDSetup   v1.Money
+	DIDMonthly v1.Money
+	SMS        v1.Money
+}
+
+var DefaultPrices = Prices{
+	DIDSetup:   v1.Money{},
+	DIDMonthly: v1.MustParseMoney("0.85"),
+	SMS:        v1.MustParseMoney("0.0075"),
+}
+
+//A voip.ms entity as the name/value pairs it was created with, i.e. a sub account or DID.
+type Record map[string]interface{}
+
+type failure struct {
+	status     string
+	httpStatus int
+}
+
+type Server struct {
+	*httptest.Server
+
+	//Credentials requests must carry. Anything is accepted while both are empty.
+	Username string
+	Password string
+
+	//Main account id used to prefix sub account names, i.e. 100000_office.
+	AccountId string
+
+	Prices Prices
+
+	//Returns the current time for order dates, SMS dates, etc.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	balance  v1.Money
+	spent    v1.Money
+	nextId   int
+	accounts []Record
+	dids     []Record
+	sms      []Record
+	clients  []Record
+	charges  map[string][]Record
+	failures map[string][]failure
+	calls    []string
+}
+
+//Starts a Server with a $100 balance.
+func NewServer() *Server {
+	s := &Server{
+		AccountId: "100000",
+		Prices:    DefaultPrices,
+		Now:       time.Now,
+		balance:   v1.MustParseMoney("100"),
+		nextId:    1,
+		charges:   map[string][]Record{},
+		failures:  map[string][]failure{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+//Returns a VOIPClient pointed at the server with its credentials.
+func (s *Server) Client(options ...v1.Option) *v1.VOIPClient {
+	return v1.NewVOIPClient(s.URL, s.Username, s.Password, options...)
+}
+
+//Makes the next call to method answer with status instead of being handled. An empty method matches any method.
+//Calls queue up, so scripting the same method twice fails its next two calls.
+func (s *Server) FailNext(method, status string) {
+	s.fail(method, failure{status: status, httpStatus: http.StatusOK})
+}
+
+//Makes the next call to method fail with an HTTP error, i.e. http.StatusServiceUnavailable.
+func (s *Server) FailNextHTTP(method string, httpStatus int) {
+	s.fail(method, failure{httpStatus: httpStatus})
+}
+
+func (s *Server) fail(method string, f failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = append(s.failures[method], f)
+}
+
+func (s *Server) SetBalance(balance v1.Money) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balance = balance
+}
+
+func (s *Server) Balance() v1.Money {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balance
+}
+
+//Methods called so far, in order, scripted failures included.
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.ParseMultipartForm(1 << 20)
+	} else {
+		r.ParseForm()
+	}
+	params := r.Form
+	method := params.Get("method")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, method)
+
+	if f, ok := s.nextFailure(method); ok {
+		if f.httpStatus != http.StatusOK {
+			http.Error(w, http.StatusText(f.httpStatus), f.httpStatus)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"status": f.status})
+		return
+	}
+
+	if s.Username != "" || s.Password != "" {
+		if params.Get("api_username") != s.Username || params.Get("api_password") != s.Password {
+			writeJSON(w, map[string]interface{}{"status": "invalid_credentials"})
+			return
+		}
+	}
+
+	handler, ok := handlers[method]
+	if !ok {
+		status := "invalid_method"
+		if method == "" {
+			status = "missing_method"
+		}
+		writeJSON(w, map[string]interface{}{"status": status})
+		return
+	}
+
+	resp := handler(s, params)
+	if _, ok := resp["status"]; !ok {
+		resp["status"] = "success"
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) nextFailure(method string) (failure, bool) {
+	for _, m := range []string{method, ""} {
+		if queue := s.failures[m]; len(queue) > 0 {
+			s.failures[m] = queue[1:]
+			return queue[0], true
+		}
+	}
+	return failure{}, false
+}
+
+func (s *Server) id() string {
+	id := strconv.Itoa(s.nextId)
+	s.nextId++
+	return id
+}
+
+//Takes amount from the balance. Returns false, leaving the balance alone, when there isn't enough.
+func (s *Server) charge(amount v1.Money) bool {
+	if amount.Cmp(s.balance) > 0 {
+		return false
+	}
+	s.balance = s.balance.Sub(amount)
+	s.spent = s.spent.Add(amount)
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+//Copies every parameter other than the method and credentials into a Record.
+func newRecord(params url.Values) Record {
+	r := Record{}
+	for k := range params {
+		switch k {
+		case "method", "api_username", "api_password":
+			continue
+		}
+		r[k] = params.Get(k)
+	}
+	return r
+}
+
+//Records matching every non empty filter.
+func filter(records []Record, filters map[string]string) []Record {
+	matched := []Record{}
+	for _, r := range records {
+		ok := true
+		for k, v := range filters {
+			if v != "" && fmt.Sprint(r[k]) != v {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+func remove(records []Record, key, value string) ([]Record, bool) {
+	for i, r := range records {
+		if fmt.Sprint(r[key]) == value {
+			return append(records[:i], records[i+1:]...), true
+		}
+	}
+	return records, false
+}
+
+//Returns the missing_<name> status for the first of names that wasn't sent, or "" when all were.
+func missing(params url.Values, names ...string) string {
+	for _, name := range names {
+		if params.Get(name) == "" {
+			return "missing_" + name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package voipmstest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stancarney/govoipms/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_SubAccounts(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	accounts := s.Client().NewAccountsAPI()
+
+	a := &v1.Account{Username: "office", Protocol: "1", AuthType: "1", DeviceType: "2", Password: "Password1", Description: "Office"}
+
+	//execute & verify
+	require.NoError(t, accounts.CreateSubAccount(a))
+	require.Equal(t, "100000_office", a.Account)
+	require.NotEqual(t, "", a.Id)
+
+	require.EqualError(t, accounts.CreateSubAccount(a), "used_username")
+
+	found, err := accounts.GetSubAccounts("100000_office")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "Office", found[0].Description)
+
+	a.Description = "Front Office"
+	require.NoError(t, accounts.SetSubAccount(a))
+	found, err = accounts.GetSubAccounts("")
+	require.NoError(t, err)
+	require.Equal(t, "Front Office", found[0].Description)
+
+	require.NoError(t, accounts.DelSubAccount(a.Id))
+	_, err = accounts.GetSubAccounts("100000_office")
+	require.EqualError(t, err, "invalid_account")
+}
+
+func TestServer_OrderDIDCharges(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	s.SetBalance(v1.MustParseMoney("1"))
+	dids := s.Client().NewDIDsAPI()
+
+	order := func(did string) *v1.DIDOrder {
+		return &v1.DIDOrder{
+			Did: did,
+			Order: v1.Order{
+				Routing:     v1.NewAccountRoute("100000_office"),
+				POP:         "1",
+				Dialtime:    60,
+				CNAM:        true,
+				BillingType: 1,
+			},
+		}
+	}
+
+	//execute & verify
+	require.NoError(t, dids.OrderDID(order("5555551234")))
+	require.Equal(t, v1.MustParseMoney("0.15"), s.Balance())
+
+	infos, err := dids.GetDIDsInfo("", "5555551234")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, "account:100000_office", infos[0].Routing.String())
+
+	infos, err = dids.GetDIDsInfo("111", "")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+
+	err = dids.OrderDID(order("5555554321"))
+	require.True(t, errors.Is(err, v1.ErrInsufficientBalance))
+	require.Equal(t, v1.MustParseMoney("0.15"), s.Balance())
+
+	require.NoError(t, dids.CancelDID("5555551234", "", false, false))
+	_, err = dids.GetDIDsInfo("", "5555551234")
+	require.EqualError(t, err, "invalid_did")
+}
+
+func TestServer_SMS(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	s.Username, s.Password = "me@example.com", "ApiPass1"
+
+	call := func(values url.Values) map[string]interface{} {
+		values.Set("api_username", s.Username)
+		values.Set("api_password", s.Password)
+		resp, err := http.PostForm(s.URL, values)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		rs := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&rs))
+		return rs
+	}
+
+	//execute & verify
+	require.Equal(t, "invalid_did", call(url.Values{"method": {"sendSMS"}, "did": {"5555551234"}, "dst": {"5555550000"}, "message": {"hi"}})["status"])
+
+	require.Equal(t, "success", call(url.Values{"method": {"orderDID"}, "did": {"5555551234"}, "routing": {"account:100000_office"}, "pop": {"1"}, "dialtime": {"60"}, "cnam": {"1"}, "billing_type": {"1"}})["status"])
+	rs := call(url.Values{"method": {"sendSMS"}, "did": {"5555551234"}, "dst": {"5555550000"}, "message": {"hi"}})
+	require.Equal(t, "success", rs["status"])
+	require.Equal(t, v1.MustParseMoney("99.1425"), s.Balance())
+
+	rs = call(url.Values{"method": {"getSMS"}, "did": {"5555551234"}})
+	require.Equal(t, "success", rs["status"])
+	require.Len(t, rs["sms"], 1)
+	sms := rs["sms"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "hi", sms["message"])
+
+	require.Equal(t, "success", call(url.Values{"method": {"deleteSMS"}, "id": {sms["id"].(string)}})["status"])
+	require.Equal(t, "no_sms", call(url.Values{"method": {"getSMS"}})["status"])
+}
+
+func TestServer_ClientCharges(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	clients := s.Client().NewClientsAPI()
+
+	c := &v1.Client{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Password: "Secret1", PhoneNumber: "5555551234"}
+	require.NoError(t, clients.SignupClient(c, c.Email, c.Password, true))
+
+	found, err := clients.GetClients("")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	id := found[0].Client
+
+	//execute
+	require.NoError(t, clients.AddPayment(id, "Deposit", v1.MustParseMoney("20"), false))
+	require.NoError(t, clients.AddCharge(id, "Monthly", v1.MustParseMoney("5.5"), false))
+	require.NoError(t, clients.AddCharge(id, "Ignored", v1.MustParseMoney("100"), true))
+
+	//verify
+	balance, err := clients.GetResellerBalance(id)
+	require.NoError(t, err)
+	require.Equal(t, "14.5000", balance.CurrentBalance.String())
+
+	charges, err := clients.GetCharges(id)
+	require.NoError(t, err)
+	require.Len(t, charges, 1)
+	require.Equal(t, v1.MustParseMoney("-5.5"), charges[0].Amount)
+	require.Equal(t, "Monthly", charges[0].Description)
+}
+
+func TestServer_ScriptedFailures(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	general := s.Client().NewGeneralAPI()
+
+	s.FailNext("getBalance", "limit_reached")
+	s.FailNextHTTP("", http.StatusServiceUnavailable)
+
+	//execute
+	_, err1 := general.GetBalance(false)
+	_, err2 := general.GetIP()
+	balance, err3 := general.GetBalance(false)
+
+	//verify
+	require.True(t, errors.Is(err1, v1.ErrRateLimited))
+	require.True(t, errors.Is(err2, v1.ErrUnavailable))
+	require.NoError(t, err3)
+	require.Equal(t, "100.0000", balance.CurrentBalance.String())
+	require.Equal(t, []string{"getBalance", "getIP", "getBalance"}, s.Calls())
+}
+
+func TestServer_Credentials(t *testing.T) {
+
+	//setup
+	s := NewServer()
+	defer s.Close()
+	s.Username, s.Password = "me@example.com", "ApiPass1"
+
+	//execute
+	_, err := v1.NewVOIPClient(s.URL, "me@example.com", "wrong").NewGeneralAPI().GetIP()
+	ip, okErr := s.Client().NewGeneralAPI().GetIP()
+
+	//verify
+	require.True(t, errors.Is(err, v1.ErrAuth))
+	require.NoError(t, okErr)
+	require.Equal(t, "127.0.0.1", ip)
+}